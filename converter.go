@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/xuri/excelize/v2"
 )
@@ -14,6 +15,8 @@ type StructConverter struct {
 	filePath        string
 	sheetName       string
 	fieldConverters map[string]FieldConverter
+	boolValues      *BoolValues
+	enums           map[string]map[string]interface{}
 }
 
 func NewStructConverter(fileName, filePath, sheetName string, opts ...WOption) *StructConverter {
@@ -21,7 +24,11 @@ func NewStructConverter(fileName, filePath, sheetName string, opts ...WOption) *
 		fileName:        fileName,
 		filePath:        filePath,
 		sheetName:       sheetName,
-		fieldConverters: make(map[string]FieldConverter),
+		fieldConverters: make(map[string]FieldConverter, len(DefaultFieldConverterMap)),
+		enums:           make(map[string]map[string]interface{}),
+	}
+	for tag, converter := range DefaultFieldConverterMap {
+		structConverter.fieldConverters[tag] = converter
 	}
 	for _, opt := range opts {
 		if err := opt(structConverter); err != nil {
@@ -41,7 +48,7 @@ func (sc *StructConverter) Converter(ctx context.Context, streamWriter *excelize
 	return
 }
 
-func (sc *StructConverter) createHeaders(ctx context.Context, streamWriter *excelize.StreamWriter, structLists interface{}) (map[int]FieldMetadata, error) {
+func (sc *StructConverter) createHeaders(ctx context.Context, streamWriter *excelize.StreamWriter, structLists interface{}) ([]FieldMetadata, error) {
 	val := reflect.ValueOf(structLists)
 	if val.Kind() != reflect.Slice {
 		return nil, fmt.Errorf("the struct list data must be a slice")
@@ -50,25 +57,62 @@ func (sc *StructConverter) createHeaders(ctx context.Context, streamWriter *exce
 		return nil, fmt.Errorf("nil struct list, no data")
 	}
 	elem := val.Index(0).Type()
-	headers := make([]interface{}, 0, elem.NumField())
-	headerSet := make(map[int]FieldMetadata)
+	fields := excelFields(elem)
+	headers := make([]interface{}, 0, len(fields))
+	headerSet := make([]FieldMetadata, 0, len(fields))
 
-	for i := 0; i < elem.NumField(); i++ {
-		field := elem.Field(i)
-		excelTag := field.Tag.Get("excel")
-		excelTags := strings.Split(excelTag, ",")
-		if len(excelTags) == 0 || excelTags[0] == "-" || strings.TrimSpace(excelTags[0]) == "" {
+	for _, ef := range fields {
+		// the columns collected by an extra field are not written
+		if ef.extra {
 			continue
 		}
-
+		field := ef.field
 		convertTag := field.Tag.Get("convert")
+		if convertTag == "" {
+			fieldType := field.Type
+			if fieldType.Kind() == reflect.Ptr {
+				fieldType = fieldType.Elem()
+			}
+			if _, ok := DefaultFieldConverterMap[fieldType.Name()]; ok && !otherBuiltinType(fieldType) {
+				convertTag = fieldType.Name()
+			}
+		}
 
-		headerSet[i] = FieldMetadata{
-			FIndex:    i,
-			Excel:     excelTags[0],
+		fieldMeta := FieldMetadata{
+			FIndex:    field.Index[len(field.Index)-1],
+			Index:     field.Index,
+			FName:     field.Name,
+			Excel:     ef.name,
 			Converter: convertTag,
+			Layout:    field.Tag.Get("layout"),
+			TZ:        strings.TrimSpace(field.Tag.Get("tz")),
+			Enum:      strings.TrimSpace(field.Tag.Get("enum")),
+		}
+		if fieldMeta.Enum != "" {
+			labels, ok := sc.enums[fieldMeta.Enum]
+			if !ok {
+				return nil, fmt.Errorf("unknown enum of field [%s]: %s", field.Name, fieldMeta.Enum)
+			}
+			fieldMeta.enum = labels
 		}
-		headers = append(headers, excelTags[0])
+		if boolTag := field.Tag.Get("bool"); boolTag != "" {
+			values, err := parseBoolTag(boolTag)
+			if err != nil {
+				return nil, fmt.Errorf("invalid bool tag of field [%s]: %v", field.Name, err)
+			}
+			fieldMeta.boolValues = &values
+		} else {
+			fieldMeta.boolValues = sc.boolValues
+		}
+		if fieldMeta.TZ != "" {
+			loc, err := time.LoadLocation(fieldMeta.TZ)
+			if err != nil {
+				return nil, fmt.Errorf("invalid tz tag of field [%s]: %v", field.Name, err)
+			}
+			fieldMeta.location = loc
+		}
+		headerSet = append(headerSet, fieldMeta)
+		headers = append(headers, ef.name)
 	}
 	//  Excel Header
 	cell, err := excelize.CoordinatesToCellName(1, 1)
@@ -81,28 +125,44 @@ func (sc *StructConverter) createHeaders(ctx context.Context, streamWriter *exce
 	return headerSet, nil
 }
 
-func (sc *StructConverter) writeData(ctx context.Context, headers map[int]FieldMetadata, streamWriter *excelize.StreamWriter, structLists interface{}) (err error) {
+func (sc *StructConverter) writeData(ctx context.Context, headers []FieldMetadata, streamWriter *excelize.StreamWriter, structLists interface{}) (err error) {
 	listVal := reflect.ValueOf(structLists)
 
 	for rIdx := 0; rIdx < listVal.Len(); rIdx++ {
 		rowData := listVal.Index(rIdx)
 		rowValues := make([]interface{}, 0, len(headers))
 
-		for col := 0; col < rowData.NumField(); col++ {
-			if fieldMeta, ok := headers[col]; ok {
-				v := rowData.Field(col).Interface()
-				if fieldMeta.Converter != "" {
-					if converter, ok := sc.fieldConverters[fieldMeta.Converter]; ok {
-						v, err = converter(v)
-						if err != nil {
-							return err
-						}
-					} else {
-						return fmt.Errorf("convert func is not registered: Convert tag [%s]", fieldMeta.Converter)
+		for _, fieldMeta := range headers {
+			var v interface{}
+			// the fields of a nil nested struct pointer are written as empty cells
+			if fieldVal, err := rowData.FieldByIndexErr(fieldMeta.Index); err == nil {
+				v = fieldVal.Interface()
+			}
+			if fieldMeta.Converter != "" {
+				if converter, ok := sc.fieldConverters[fieldMeta.Converter]; ok {
+					v, err = converter(v)
+					if err != nil {
+						return err
 					}
+				} else {
+					return fmt.Errorf("convert func is not registered: Convert tag [%s]", fieldMeta.Converter)
+				}
+			}
+			v = indirect(v)
+			if fieldMeta.enum != nil && v != nil {
+				if label, ok := fieldMeta.enum[fmt.Sprint(v)]; ok {
+					v = label
+				}
+			}
+			switch value := v.(type) {
+			case time.Time:
+				v = formatTime(value, fieldMeta)
+			case bool:
+				if fieldMeta.boolValues != nil {
+					v = fieldMeta.boolValues.Format(value)
 				}
-				rowValues = append(rowValues, v)
 			}
+			rowValues = append(rowValues, v)
 		}
 
 		// write
@@ -116,3 +176,35 @@ func (sc *StructConverter) writeData(ctx context.Context, headers map[int]FieldM
 	}
 	return nil
 }
+
+// indirect dereferences pointer values, a nil pointer is written as an empty cell.
+func indirect(v interface{}) interface{} {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr {
+		return v
+	}
+	if rv.IsNil() {
+		return nil
+	}
+	return rv.Elem().Interface()
+}
+
+// formatTime applies the layout and tz tags of a time field. With a layout the
+// time is written as text in that layout, with only a tz it is written as a date
+// cell holding the wall clock in that time zone.
+func formatTime(t time.Time, fieldMeta FieldMetadata) interface{} {
+	if fieldMeta.location != nil {
+		t = t.In(fieldMeta.location)
+	}
+	if fieldMeta.Layout != "" {
+		if t.IsZero() {
+			return ""
+		}
+		layout, _, _ := strings.Cut(fieldMeta.Layout, "|")
+		return t.Format(layout)
+	}
+	if fieldMeta.location != nil {
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+	}
+	return t
+}
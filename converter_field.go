@@ -1,5 +1,44 @@
 package excel2struct
 
-var DefaultFieldConverterMap map[string]FieldConverter
+import (
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// DefaultFieldConverterMap holds the converters used for field types without a convert tag.
+var DefaultFieldConverterMap = map[string]FieldConverter{
+	"Decimal":  FieldConverterDecimal,
+	"Duration": FieldConverterDuration,
+}
 
 type FieldConverter func(field interface{}) (interface{}, error)
+
+// FieldConverterDecimal writes a decimal as a number, or as text when it does not
+// survive the round trip through float64.
+func FieldConverterDecimal(field interface{}) (interface{}, error) {
+	switch v := indirect(field).(type) {
+	case nil:
+		return nil, nil
+	case decimal.Decimal:
+		if f := v.InexactFloat64(); decimal.NewFromFloat(f).Equal(v) {
+			return f, nil
+		}
+		return v.String(), nil
+	default:
+		return nil, fmt.Errorf("unable to convert %T to decimal", field)
+	}
+}
+
+// FieldConverterDuration writes a duration as text, e.g. "1h30m0s", which FieldParserDuration reads back.
+func FieldConverterDuration(field interface{}) (interface{}, error) {
+	switch v := indirect(field).(type) {
+	case nil:
+		return nil, nil
+	case time.Duration:
+		return v.String(), nil
+	default:
+		return nil, fmt.Errorf("unable to convert %T to duration", field)
+	}
+}
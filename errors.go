@@ -0,0 +1,42 @@
+package excel2struct
+
+import "errors"
+
+// The fatal errors returned when a row can not be skipped match these errors with errors.Is.
+var (
+	ErrRequired      = errors.New("required field is empty")
+	ErrParse         = errors.New("unable to parse field")
+	ErrNotRegistered = errors.New("parsing func is not registered")
+	ErrFieldMatch    = errors.New("no excel title matching found")
+	ErrEIndexExceed  = errors.New("the Excel column index settings exceed the line length")
+)
+
+var codeErrors = map[int]error{
+	ERROR_REQUIRED:      ErrRequired,
+	ERROR_PARSE:         ErrParse,
+	ERROR_NOT_REGISTED:  ErrNotRegistered,
+	ERROR_FIELD_MATCH:   ErrFieldMatch,
+	ERROR_EINDEX_EXCEED: ErrEIndexExceed,
+}
+
+// CellError is a fatal error of a cell or a column, e.g. an empty required field
+// when the row can not be skipped. Use errors.As(err, &CellError{}) to get where
+// the error happened, and errors.Is with the Err variables to tell what happened.
+type CellError struct {
+	ErrorInfo
+	Err error // underlying error, e.g. the error of the parser, nil if there is none
+}
+
+func (e CellError) Error() string {
+	return e.ErrorMsg
+}
+
+func (e CellError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is the Err variable of the error code.
+func (e CellError) Is(target error) bool {
+	err, ok := codeErrors[e.ErrorCode]
+	return ok && err == target
+}
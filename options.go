@@ -1,6 +1,12 @@
 package excel2struct
 
-import "runtime"
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"time"
+)
 
 type Option func(excelParser *ExcelParser) error
 
@@ -21,6 +27,155 @@ func WithWorkers(num int) Option {
 	}
 }
 
+// WithHeaderMatch relaxes how excel titles are matched with the `excel` tag,
+// e.g. WithHeaderMatch(HeaderIgnoreCase | HeaderIgnoreWidth | HeaderIgnoreSpace).
+func WithHeaderMatch(flags HeaderMatch) Option {
+	return func(excelParser *ExcelParser) error {
+		excelParser.headerMatch = flags
+		return nil
+	}
+}
+
+// WithFuzzyHeaders matches the fields left unmatched by their aliases and excelRegex
+// tags with the unused column whose title is closest to one of their aliases,
+// if at most distance characters have to be edited.
+func WithFuzzyHeaders(distance int) Option {
+	return func(excelParser *ExcelParser) error {
+		if distance < 0 {
+			return errors.New("negative edit distance")
+		}
+		excelParser.fuzzyDistance = distance
+		return nil
+	}
+}
+
+// WithStrictColumns fails the parse if the title row misses the column of an
+// optional field, instead of parsing its cells as empty and reporting a warning.
+func WithStrictColumns() Option {
+	return func(excelParser *ExcelParser) error {
+		excelParser.strictColumns = true
+		return nil
+	}
+}
+
+// WithDisallowUnknownColumns fails the parse if titled columns match no struct field,
+// unless the struct has an extra field tagged `excel:",extra"` collecting them.
+func WithDisallowUnknownColumns() Option {
+	return func(excelParser *ExcelParser) error {
+		excelParser.disallowUnknown = true
+		return nil
+	}
+}
+
+// WithExhaustiveErrors parses every field of a row and records all their errors,
+// instead of stopping at the first required field that is empty or fails to parse.
+// The invalid rows are still left out of the output.
+func WithExhaustiveErrors() Option {
+	return func(excelParser *ExcelParser) error {
+		excelParser.exhaustive = true
+		return nil
+	}
+}
+
+// WithStrictInteger makes the built-in integer parsers reject fractional values
+// such as "12.7" instead of truncating them. Parsers registered with WithFieldParser
+// are kept, whatever the order of the options.
+func WithStrictInteger() Option {
+	return func(excelParser *ExcelParser) error {
+		for tag, parser := range strictIntegerParsers {
+			if sameParser(excelParser.fieldParsers[tag], DefaultFieldParserMap[tag]) {
+				excelParser.fieldParsers[tag] = parser
+			}
+		}
+		return nil
+	}
+}
+
+// WithPrecision sets the decimal places parsed floats and decimals are rounded to,
+// unless a field sets its own `precision` tag. A negative precision disables rounding, which is the default.
+func WithPrecision(precision int) Option {
+	return func(excelParser *ExcelParser) error {
+		excelParser.precision = precision
+		return nil
+	}
+}
+
+// WithLocation parses times without a time zone in loc instead of UTC,
+// unless a field sets its own `tz` tag.
+func WithLocation(loc *time.Location) Option {
+	return func(excelParser *ExcelParser) error {
+		if loc == nil {
+			return errors.New("nil location")
+		}
+		excelParser.location = loc
+		return nil
+	}
+}
+
+// WithNumberFormat normalizes the values of every numeric field with format,
+// unless a field refers to a locale with its own `number` tag.
+func WithNumberFormat(format NumberFormat) Option {
+	return func(excelParser *ExcelParser) error {
+		excelParser.numberFormat = &format
+		return nil
+	}
+}
+
+// WithNumberLocale registers a number format the `number` tag can refer to by name.
+func WithNumberLocale(name string, format NumberFormat) Option {
+	return func(excelParser *ExcelParser) error {
+		excelParser.numberLocales[name] = format
+		return nil
+	}
+}
+
+// WithBoolValues replaces the vocabulary of every bool field, unless a field sets its own `bool` tag.
+func WithBoolValues(values BoolValues) Option {
+	return func(excelParser *ExcelParser) error {
+		excelParser.boolValues = &values
+		return nil
+	}
+}
+
+// WithDate1904 reads Excel serial date numbers in the 1904 date system.
+// xlsx workbooks using the 1904 date system are detected without this option.
+func WithDate1904() Option {
+	return func(excelParser *ExcelParser) error {
+		excelParser.date1904 = true
+		return nil
+	}
+}
+
+// WithRawCellValue reads the raw values of xlsx cells instead of their formatted
+// display strings, e.g. dates are read as serial date numbers and numbers keep all their digits.
+func WithRawCellValue() Option {
+	return func(excelParser *ExcelParser) error {
+		excelParser.rawCellValue = true
+		return nil
+	}
+}
+
+// WithParallelSheets makes ReadSheets parse the sheets of a workbook concurrently.
+func WithParallelSheets() Option {
+	return func(excelParser *ExcelParser) error {
+		excelParser.parallelSheets = true
+		return nil
+	}
+}
+
+// WithEnum registers an enum the `enum` tag can refer to by name. Fields with
+// this tag are set to the code of the label in the cell, unknown labels are row errors.
+func WithEnum(name string, mapping map[string]interface{}) Option {
+	return func(excelParser *ExcelParser) error {
+		enum := make(map[string]interface{}, len(mapping))
+		for label, code := range mapping {
+			enum[strings.TrimSpace(label)] = code
+		}
+		excelParser.enums[name] = enum
+		return nil
+	}
+}
+
 type WOption func(structConverter *StructConverter) error
 
 func WithFieldConverter(tag string, converter FieldConverter) WOption {
@@ -29,3 +184,26 @@ func WithFieldConverter(tag string, converter FieldConverter) WOption {
 		return nil
 	}
 }
+
+// WithBoolConverter writes every bool field with the first values of the vocabulary,
+// unless a field sets its own `bool` tag.
+func WithBoolConverter(values BoolValues) WOption {
+	return func(structConverter *StructConverter) error {
+		structConverter.boolValues = &values
+		return nil
+	}
+}
+
+// WithEnumConverter registers the enum given to WithEnum for the converter.
+// Fields with an `enum` tag are written as the label of their code, codes are
+// compared by their fmt.Sprint form and unknown codes are written as they are.
+func WithEnumConverter(name string, mapping map[string]interface{}) WOption {
+	return func(structConverter *StructConverter) error {
+		labels := make(map[string]interface{}, len(mapping))
+		for label, code := range mapping {
+			labels[fmt.Sprint(code)] = label
+		}
+		structConverter.enums[name] = labels
+		return nil
+	}
+}
@@ -5,31 +5,68 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"regexp"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/lisongxi/goutils"
+	"github.com/xuri/excelize/v2"
 )
 
 type ExcelParser struct {
-	fileType     string
-	headerIndex  int
-	sheetName    string
-	fieldParsers map[string]FieldParser
-	RowErrs      *[]ErrorInfo
-	errChan      chan ErrorInfo
-	workers      int
+	fileType        string
+	headerIndex     int
+	headerMatch     HeaderMatch
+	fuzzyDistance   int
+	headerReport    *HeaderMatchReport
+	strictColumns   bool
+	exhaustive      bool
+	disallowUnknown bool
+	warnings        []ErrorInfo
+	fieldOrder      []string // keys of the struct fields in column order, set by parseTitle
+	sheetName       string
+	fieldParsers    map[string]FieldParser
+	RowErrs         *[]ErrorInfo // errors of the last Parse or Reader call
+	errChan         chan ErrorInfo
+	workers         int
+	parallelSheets  bool
+	precision       int
+	date1904        bool
+	rawCellValue    bool
+	location        *time.Location
+	numberFormat    *NumberFormat
+	numberLocales   map[string]NumberFormat
+	boolValues      *BoolValues
+	enums           map[string]map[string]interface{}
+	mu              *sync.Mutex
+}
+
+// Result holds what a single parse call collected.
+type Result struct {
+	RowErrs  []ErrorInfo
+	Header   *HeaderMatchReport // how the title row was matched, nil if there was no title row
+	Warnings []ErrorInfo        // problems that did not stop the parse, e.g. missing optional columns
 }
 
 func NewExcelParser(fileType string, headerIndex int, sheetName string, opts ...Option) (*ExcelParser, error) {
 	excelParser := &ExcelParser{
-		fileType:     fileType,
-		headerIndex:  headerIndex,
-		sheetName:    sheetName,
-		fieldParsers: DefaultFieldParserMap,
-		RowErrs:      &[]ErrorInfo{},
-		errChan:      make(chan ErrorInfo, 10),
+		fileType:      fileType,
+		headerIndex:   headerIndex,
+		sheetName:     sheetName,
+		fieldParsers:  make(map[string]FieldParser, len(DefaultFieldParserMap)),
+		numberLocales: make(map[string]NumberFormat, len(NumberLocales)),
+		enums:         make(map[string]map[string]interface{}),
+		RowErrs:       &[]ErrorInfo{},
+		precision:     -1,
+		mu:            &sync.Mutex{},
+	}
+	for tag, parser := range DefaultFieldParserMap {
+		excelParser.fieldParsers[tag] = parser
+	}
+	for name, numberFormat := range NumberLocales {
+		excelParser.numberLocales[name] = numberFormat
 	}
 
 	for _, opt := range opts {
@@ -40,7 +77,57 @@ func NewExcelParser(fileType string, headerIndex int, sheetName string, opts ...
 	return excelParser, nil
 }
 
+// Parse parses rows into output and stores the row errors of this call in ep.RowErrs.
+// Use ParseWithResult when ep is shared between goroutines.
 func (ep *ExcelParser) Parse(ctx context.Context, rows [][]string, output interface{}, skip bool) (err error) {
+	result, err := ep.ParseWithResult(ctx, rows, output, skip)
+	ep.keepRowErrs(result)
+	return
+}
+
+// ParseWithResult parses rows into output and returns the row errors of this call.
+// It is safe to call concurrently on the same ExcelParser.
+func (ep *ExcelParser) ParseWithResult(ctx context.Context, rows [][]string, output interface{}, skip bool) (*Result, error) {
+	call := ep.session()
+	err := call.parse(ctx, rows, output, skip)
+	return call.result(), err
+}
+
+// session returns a copy of ep with its own error collection and channel,
+// so that concurrent calls do not share any state.
+func (ep *ExcelParser) session() *ExcelParser {
+	// RowErrs is replaced by keepRowErrs while other calls copy ep
+	ep.mu.Lock()
+	call := *ep
+	ep.mu.Unlock()
+	call.RowErrs = &[]ErrorInfo{}
+	call.errChan = make(chan ErrorInfo, 10)
+	call.headerReport = nil
+	call.warnings = nil
+	call.fieldOrder = nil
+	return &call
+}
+
+func (ep *ExcelParser) result() *Result {
+	return &Result{RowErrs: *ep.RowErrs, Header: ep.headerReport, Warnings: ep.warnings}
+}
+
+// errorInfos returns the warnings followed by the row errors, for the helpers
+// returning a single list of errors such as ParseAs and StreamAs.
+func (result *Result) errorInfos() []ErrorInfo {
+	if len(result.Warnings) == 0 {
+		return result.RowErrs
+	}
+	return append(append([]ErrorInfo{}, result.Warnings...), result.RowErrs...)
+}
+
+func (ep *ExcelParser) keepRowErrs(result *Result) {
+	ep.mu.Lock()
+	defer ep.mu.Unlock()
+	ep.RowErrs = &result.RowErrs
+}
+
+func (ep *ExcelParser) parse(ctx context.Context, rows [][]string, output interface{}, skip bool) (err error) {
 
 	outputValue := reflect.ValueOf(output)
 	outputType := reflect.TypeOf(output)
@@ -56,54 +143,9 @@ func (ep *ExcelParser) Parse(ctx context.Context, rows [][]string, output interf
 	}
 
 	structType := elemType.Elem()
-	if structType.Kind() != reflect.Struct {
-		return fmt.Errorf("the pointer of the slice element must point to a struct")
-	}
-
-	structFieldMetaMap := make(map[string]FieldMetadata)
-	for i := 0; i < structType.NumField(); i++ {
-		field := structType.Field(i)
-		excelTag := field.Tag.Get("excel")
-		excelTags := strings.Split(excelTag, ",")
-		if len(excelTags) == 0 || excelTags[0] == "-" || strings.TrimSpace(excelTags[0]) == "" {
-			continue
-		}
-
-		eIndexTag := field.Tag.Get("eIndex")
-		var eIndex int
-		if eIndexTag != "" && eIndexTag != "-" {
-			eIndex, _ = strconv.Atoi(strings.TrimSpace(eIndexTag))
-		}
-
-		required := strings.Contains(excelTag, "required")
-		defaultVal := field.Tag.Get("default")
-
-		parserTag := field.Tag.Get("parser")
-		parserTags := strings.Split(parserTag, ",")
-		if parserTag == "" || len(parserTags) == 0 || parserTags[0] == "-" {
-			parser := structType.Field(i).Type.Name()
-			fieldMetadata := FieldMetadata{
-				FIndex:   i,
-				FName:    field.Name,
-				Excel:    strings.TrimSpace(excelTags[0]),
-				EIndex:   eIndex,
-				Parser:   parser,
-				Required: required,
-				Default:  defaultVal,
-			}
-			structFieldMetaMap[strings.TrimSpace(excelTags[0])] = fieldMetadata
-			continue
-		}
-		fieldMetadata := FieldMetadata{
-			FIndex:   i,
-			FName:    field.Name,
-			Excel:    strings.TrimSpace(excelTags[0]),
-			EIndex:   eIndex,
-			Parser:   strings.TrimSpace(parserTags[0]),
-			Required: required,
-			Default:  defaultVal,
-		}
-		structFieldMetaMap[strings.TrimSpace(excelTags[0])] = fieldMetadata
+	structFieldMetaMap, err := ep.parseStructTags(structType)
+	if err != nil {
+		return
 	}
 
 	if ep.headerIndex >= len(rows) {
@@ -124,144 +166,384 @@ func (ep *ExcelParser) Parse(ctx context.Context, rows [][]string, output interf
 		results := reflect.MakeSlice(sliceType, 0, len(rows))
 		for idx, row := range rows {
 			out := reflect.New(structType)
-			parsedErr := ep.parseRowToStruct(ctx, idx+ep.headerIndex+2, structFieldMetaMap, row, titleMap, out, skip)
+			valid, parsedErr := ep.parseRowToStruct(ctx, idx+ep.headerIndex+2, structFieldMetaMap, row, titleMap, out, skip)
 			if parsedErr != nil {
 				return parsedErr
 			}
-			results = reflect.Append(results, out)
+			if valid {
+				results = reflect.Append(results, out)
+			}
 		}
 		outputValue.Elem().Set(results)
 	} else {
 		var wg sync.WaitGroup
 		wg.Add(1)
 		ep.AppendErrors(ctx, &wg)
-		ep.parseWithWorkers(ctx, structFieldMetaMap, titleMap, structType, sliceType, outputValue, rows, skip)
+		err = ep.parseWithWorkers(ctx, structFieldMetaMap, titleMap, structType, sliceType, outputValue, rows, skip)
 		wg.Wait()
 	}
 
 	return
 }
 
-func (ep *ExcelParser) parseRowToStruct(ctx context.Context, rowIndex int, structFieldMetaMap map[string]FieldMetadata, row []string, titleMap map[string]int, out reflect.Value, skip bool) (err error) {
+// parseStructTags collects the metadata of every struct field carrying an `excel` tag.
+func (ep *ExcelParser) parseStructTags(structType reflect.Type) (map[string]FieldMetadata, error) {
+	if structType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("the pointer of the slice element must point to a struct")
+	}
+
+	structFieldMetaMap := make(map[string]FieldMetadata)
+	for _, ef := range excelFields(structType) {
+		field := ef.field
+		if ef.extra {
+			if _, ok := structFieldMetaMap[""]; ok {
+				return nil, fmt.Errorf("several extra fields in struct [%s]", structType.Name())
+			}
+			if t := field.Type; t.Kind() != reflect.Map || t.Key().Kind() != reflect.String || t.Elem().Kind() != reflect.String {
+				return nil, fmt.Errorf("extra field [%s] must be a map[string]string", field.Name)
+			}
+			structFieldMetaMap[""] = FieldMetadata{
+				FIndex: field.Index[len(field.Index)-1],
+				Index:  field.Index,
+				FName:  field.Name,
+				Extra:  true,
+			}
+			continue
+		}
+
+		eIndexTag := field.Tag.Get("eIndex")
+		var eIndex int
+		if eIndexTag != "" && eIndexTag != "-" {
+			eIndex, _ = strconv.Atoi(strings.TrimSpace(eIndexTag))
+		}
+
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			// pointer fields are parsed like their element type, empty cells leave them nil
+			fieldType = fieldType.Elem()
+		}
+		parser := fieldType.Name()
+		parserTag := field.Tag.Get("parser")
+		parserTags := strings.Split(parserTag, ",")
+		tagged := parserTag != "" && parserTags[0] != "-"
+		if tagged {
+			parser = strings.TrimSpace(parserTags[0])
+		}
+
+		precision := ep.precision
+		if precisionTag := strings.TrimSpace(field.Tag.Get("precision")); precisionTag != "" {
+			var err error
+			if precision, err = strconv.Atoi(precisionTag); err != nil {
+				return nil, fmt.Errorf("invalid precision tag of field [%s]: %v", field.Name, err)
+			}
+		}
+
+		fieldMetadata := FieldMetadata{
+			FIndex:    field.Index[len(field.Index)-1],
+			Index:     field.Index,
+			FName:     field.Name,
+			Excel:     ef.name,
+			Aliases:   ef.aliases,
+			EIndex:    eIndex,
+			Parser:    parser,
+			Required:  hasTag(ef.tags, "required"),
+			Default:   field.Tag.Get("default"),
+			Precision: precision,
+			Layout:    field.Tag.Get("layout"),
+			TZ:        strings.TrimSpace(field.Tag.Get("tz")),
+			Number:    strings.TrimSpace(field.Tag.Get("number")),
+			Bool:      field.Tag.Get("bool"),
+			Enum:      strings.TrimSpace(field.Tag.Get("enum")),
+			Regex:     field.Tag.Get("excelRegex"),
+			Validate:  field.Tag.Get("validate"),
+		}
+		if fieldMetadata.Validate != "" {
+			loc, err := ep.fieldLocation(fieldMetadata)
+			if err != nil {
+				return nil, err
+			}
+			if fieldMetadata.validators, err = parseValidateTag(fieldMetadata.Validate, fieldType, loc); err != nil {
+				return nil, fmt.Errorf("invalid validate tag of field [%s]: %v", field.Name, err)
+			}
+		}
+		if fieldMetadata.Regex != "" {
+			regex, err := regexp.Compile(fieldMetadata.Regex)
+			if err != nil {
+				return nil, fmt.Errorf("invalid excelRegex tag of field [%s]: %v", field.Name, err)
+			}
+			fieldMetadata.regex = regex
+		}
+		if fieldMetadata.Enum != "" {
+			enum, ok := ep.enums[fieldMetadata.Enum]
+			if !ok {
+				return nil, fmt.Errorf("unknown enum of field [%s]: %s", field.Name, fieldMetadata.Enum)
+			}
+			fieldMetadata.enum = enum
+		}
+		fieldParser, err := ep.resolveParser(fieldMetadata, tagged, fieldType)
+		if err != nil {
+			return nil, err
+		}
+		fieldMetadata.parse = fieldParser
+		if fieldMetadata.parse != nil && numberParsers[parser] && (tagged || !otherBuiltinType(fieldType)) {
+			numberFormat := ep.numberFormat
+			if fieldMetadata.Number != "" {
+				nf, ok := ep.numberLocales[fieldMetadata.Number]
+				if !ok {
+					return nil, fmt.Errorf("unknown number locale of field [%s]: %s", field.Name, fieldMetadata.Number)
+				}
+				numberFormat = &nf
+			}
+			if numberFormat != nil {
+				fieldMetadata.parse = numberParser(fieldMetadata.parse, *numberFormat)
+			}
+		}
+		if fieldMetadata.parse != nil && precision >= 0 {
+			fieldMetadata.parse = roundParser(fieldMetadata.parse, precision)
+		}
+		structFieldMetaMap[ef.name] = fieldMetadata
+	}
+	return structFieldMetaMap, nil
+}
+
+// builtinTimeParser reports whether the time parser is still the built-in one,
+// parsers registered with WithFieldParser are never replaced.
+func (ep *ExcelParser) builtinTimeParser(unixNano bool) bool {
+	if unixNano {
+		return sameParser(ep.fieldParsers["unixNano"], FieldParserTimeUnixNano)
+	}
+	return sameParser(ep.fieldParsers["Time"], FieldParserTime)
+}
+
+// resolveParser returns the parser registered for the parser tag or the field type.
+// Without a parser tag, unregistered types implementing encoding.TextUnmarshaler
+// or sql.Scanner are decoded through these interfaces. It returns nil if no parser is found.
+// Time fields with a layout or tz tag, or parsed with WithLocation, WithRawCellValue
+// or the 1904 date system, get their own time parser, and so do bool fields with a bool tag or WithBoolValues,
+// unless the parser of the type was registered with WithFieldParser.
+func (ep *ExcelParser) resolveParser(fieldMeta FieldMetadata, tagged bool, fieldType reflect.Type) (FieldParser, error) {
+	if unixNano, ok := timeParsers[fieldMeta.Parser]; ok && ep.builtinTimeParser(unixNano) && (fieldMeta.Layout != "" || fieldMeta.TZ != "" || ep.location != nil || ep.date1904 || ep.rawCellValue) {
+		layouts := timeLayouts
+		if fieldMeta.Layout != "" {
+			layouts = strings.Split(fieldMeta.Layout, "|")
+		}
+		loc, err := ep.fieldLocation(fieldMeta)
+		if err != nil {
+			return nil, err
+		}
+		return newTimeParser(unixNano, layouts, loc, ep.date1904, ep.rawCellValue), nil
+	}
+	if fieldMeta.Parser == "bool" && sameParser(ep.fieldParsers["bool"], FieldParserBool) && (fieldMeta.Bool != "" || ep.boolValues != nil) {
+		if fieldMeta.Bool == "" {
+			return newBoolParser(*ep.boolValues), nil
+		}
+		values, err := parseBoolTag(fieldMeta.Bool)
+		if err != nil {
+			return nil, fmt.Errorf("invalid bool tag of field [%s]: %v", fieldMeta.FName, err)
+		}
+		return newBoolParser(values), nil
+	}
+	if fieldParser, registered := ep.fieldParsers[fieldMeta.Parser]; registered && (tagged || !otherBuiltinType(fieldType)) {
+		return fieldParser, nil
+	}
+	if tagged {
+		return nil, nil
+	}
+	ptrType := reflect.PointerTo(fieldType)
+	switch {
+	case ptrType.Implements(textUnmarshalerType):
+		return textUnmarshalerParser(fieldType), nil
+	case ptrType.Implements(scannerType):
+		return scannerParser(fieldType), nil
+	}
+	return nil, nil
+}
+
+// fieldLocation returns the time zone times without one are read in by the field.
+func (ep *ExcelParser) fieldLocation(fieldMeta FieldMetadata) (*time.Location, error) {
+	if fieldMeta.TZ != "" {
+		loc, err := time.LoadLocation(fieldMeta.TZ)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tz tag of field [%s]: %v", fieldMeta.FName, err)
+		}
+		return loc, nil
+	}
+	if ep.location != nil {
+		return ep.location, nil
+	}
+	return time.UTC, nil
+}
+
+// parseRowToStruct parses row into out and reports whether the row is valid.
+// Invalid rows are left out of the output, their errors are recorded, or returned
+// if the row can not be skipped. A row is invalid if a required field is empty or
+// fails to parse, or if a row hook rejects it. Without WithExhaustiveErrors the
+// fields after the first failing required field are not parsed.
+func (ep *ExcelParser) parseRowToStruct(ctx context.Context, rowIndex int, structFieldMetaMap map[string]FieldMetadata, row []string, titleMap map[string]int, out reflect.Value, skip bool) (bool, error) {
 	if out.Kind() != reflect.Ptr {
-		return fmt.Errorf("the slice element must be a pointer")
+		return false, fmt.Errorf("the slice element must be a pointer")
 	}
 	if !out.IsValid() {
-		return fmt.Errorf("the slice element is invalid")
+		return false, fmt.Errorf("the slice element is invalid")
 	}
 
 	outElem := out.Elem()
 
-	for excelTag, fieldMeta := range structFieldMetaMap {
-		tIdx, ok := titleMap[excelTag]
-		if !ok {
-			return fmt.Errorf(ERROR_TYPE[ERROR_FIELD_MATCH], excelTag)
-		}
+	if rejected, err := ep.beforeRow(ctx, rowIndex, row, out, skip); rejected || err != nil {
+		return false, err
+	}
 
-		if fieldMeta.EIndex > 0 && tIdx != fieldMeta.EIndex {
-			tIdx = fieldMeta.EIndex - 1
+	valid := true
+	for _, excelTag := range ep.fieldOrder {
+		fieldMeta := structFieldMetaMap[excelTag]
+		if fieldMeta.Extra {
+			if err := setField(fieldByIndex(outElem, fieldMeta.Index), ep.extraColumns(row)); err != nil {
+				return false, fmt.Errorf("unable to set field [%s]: %v", fieldMeta.FName, err)
+			}
+			continue
 		}
 
+		// the cells of missing optional columns are empty
 		var field string
-		if tIdx < len(row) {
-			field = row[tIdx]
+		col, ok := titleMap[excelTag]
+		if !ok {
+			col = -1
+		} else if col < len(row) {
+			field = row[col]
 		}
+		cell := field
 		// set default value
 		if field == "" && fieldMeta.Default != "" {
 			field = fieldMeta.Default
 		}
 		if field == "" {
-			if !skip && fieldMeta.Required {
-				return fmt.Errorf(ERROR_TYPE[ERROR_REQUIRED], excelTag, rowIndex)
+			if !fieldMeta.Required {
+				continue
 			}
-			if fieldMeta.Required {
-				ei := ErrorInfo{
-					Row:       rowIndex,
-					Column:    excelTag,
-					ErrorCode: ERROR_REQUIRED,
-					ErrorMsg:  fmt.Sprintf(ERROR_TYPE[ERROR_REQUIRED], excelTag, rowIndex),
-				}
-				if ep.workers == 0 {
-					*ep.RowErrs = append(*ep.RowErrs, ei)
-				} else {
-					ep.errChan <- ei
-				}
-
-				return nil
+			ei := ep.cellErrorInfo(rowIndex, col, excelTag, fieldMeta.FName, cell)
+			ei.ErrorCode = ERROR_REQUIRED
+			ei.ErrorMsg = fmt.Sprintf(ERROR_TYPE[ERROR_REQUIRED], excelTag, rowIndex)
+			if !skip {
+				return false, CellError{ErrorInfo: ei}
+			}
+			ep.recordError(ei)
+			if !ep.exhaustive {
+				return false, nil
 			}
+			valid = false
 			continue
 		}
 
-		fieldParser, registered := ep.fieldParsers[fieldMeta.Parser]
-		if !registered {
-			return fmt.Errorf(ERROR_TYPE[ERROR_NOT_REGISTED], fieldMeta.Parser)
-		}
-
-		value, err := fieldParser(field)
-		if err != nil {
-			if !skip && fieldMeta.Required {
-				return fmt.Errorf(ERROR_TYPE[ERROR_PARSE], fieldMeta.FName, fieldMeta.Required, err)
+		var (
+			value     interface{}
+			errorCode int
+			errorMsg  string
+			cause     error
+		)
+		if fieldMeta.enum != nil {
+			// enum fields are set to the code of the label, without a parser
+			code, ok := fieldMeta.enum[strings.TrimSpace(field)]
+			if !ok {
+				errorCode = ERROR_ENUM
+				errorMsg = fmt.Sprintf(ERROR_TYPE[ERROR_ENUM], field, fieldMeta.Enum, fieldMeta.FName)
 			}
-			ei := ErrorInfo{
-				Row:       rowIndex,
-				Column:    excelTag,
-				ErrorCode: ERROR_PARSE,
-				ErrorMsg:  fmt.Sprintf(ERROR_TYPE[ERROR_PARSE], fieldMeta.FName, fieldMeta.Required, err),
+			value = code
+		} else {
+			if fieldMeta.parse == nil {
+				ei := ep.cellErrorInfo(rowIndex, col, excelTag, fieldMeta.FName, cell)
+				ei.ErrorCode = ERROR_NOT_REGISTED
+				ei.ErrorMsg = fmt.Sprintf(ERROR_TYPE[ERROR_NOT_REGISTED], fieldMeta.Parser)
+				return false, CellError{ErrorInfo: ei}
 			}
-			if ep.workers == 0 {
-				*ep.RowErrs = append(*ep.RowErrs, ei)
-			} else {
-				ep.errChan <- ei
+			if value, cause = fieldMeta.parse(field); cause != nil {
+				errorCode = ERROR_PARSE
+				errorMsg = fmt.Sprintf(ERROR_TYPE[ERROR_PARSE], fieldMeta.FName, fieldMeta.Required, cause)
 			}
-
-			if fieldMeta.Required {
-				return nil
+		}
+		if errorCode == 0 {
+			if rule, ok := fieldMeta.validate(value); !ok {
+				errorCode = ERROR_VALIDATE
+				errorMsg = fmt.Sprintf(ERROR_TYPE[ERROR_VALIDATE], fieldMeta.FName, rule, field)
 			}
-			continue
 		}
-
-		thisField := outElem.Field(fieldMeta.FIndex)
-		if !thisField.CanSet() && !thisField.IsValid() {
-			return fmt.Errorf("field not found in struct or cannot be set")
+		if errorCode == 0 {
+			thisField := fieldByIndex(outElem, fieldMeta.Index)
+			if !thisField.CanSet() && !thisField.IsValid() {
+				return false, fmt.Errorf("field not found in struct or cannot be set")
+			}
+			// numbers that do not fit the field fail like unparsable cells
+			if err := setField(thisField, value); errors.Is(err, errNumberRange) {
+				errorCode, cause = ERROR_PARSE, err
+				errorMsg = fmt.Sprintf(ERROR_TYPE[ERROR_PARSE], fieldMeta.FName, fieldMeta.Required, err)
+			} else if err != nil {
+				return false, fmt.Errorf("unable to set field [%s]: %v", fieldMeta.FName, err)
+			}
 		}
-
-		rvo := reflect.ValueOf(value)
-		if thisField.Kind() == reflect.Ptr && rvo.IsZero() {
-			thisField.Set(reflect.Zero(thisField.Type()))
+		if errorCode != 0 {
+			ei := ep.cellErrorInfo(rowIndex, col, excelTag, fieldMeta.FName, cell)
+			ei.ErrorCode = errorCode
+			ei.ErrorMsg = errorMsg
+			if !skip && fieldMeta.Required {
+				return false, CellError{ErrorInfo: ei, Err: cause}
+			}
+			ep.recordError(ei)
+			if fieldMeta.Required {
+				if !ep.exhaustive {
+					return false, nil
+				}
+				valid = false
+			}
 			continue
 		}
-
-		thisField.Set(rvo)
+	}
+	if !valid {
+		return false, nil
 	}
 
-	return nil
+	if rejected, err := ep.afterRow(ctx, rowIndex, structFieldMetaMap, row, titleMap, out, skip); rejected || err != nil {
+		return false, err
+	}
+	return true, nil
 }
 
-func (ep *ExcelParser) parseTitle(row []string, structFieldMetaMap map[string]FieldMetadata) (map[string]int, error) {
-	titleMap := make(map[string]int)
-
-	for idx, title := range row {
-		trimmedTitle := strings.TrimSpace(title)
-		if _, ok := titleMap[trimmedTitle]; !ok {
-			titleMap[trimmedTitle] = idx
+// extraColumns maps the titles of the columns no field matches to their cells in row.
+func (ep *ExcelParser) extraColumns(row []string) map[string]string {
+	extra := make(map[string]string, len(ep.headerReport.Unused))
+	for _, column := range ep.headerReport.Unused {
+		var cell string
+		if column.Index < len(row) {
+			cell = row[column.Index]
 		}
+		extra[column.Title] = cell
 	}
+	return extra
+}
 
-	for field, fieldMeta := range structFieldMetaMap {
-		if fieldMeta.EIndex > len(row) {
-			return nil, fmt.Errorf(ERROR_TYPE[ERROR_EINDEX_EXCEED], fieldMeta.FName)
-		}
-
-		if !fieldMeta.Required {
-			continue
-		}
-
-		if _, found := titleMap[field]; !found {
-			return nil, fmt.Errorf("required field '%s' not found in excel title row", field)
-		}
+// cellErrorInfo returns the ErrorInfo of the cell in column col of the row, without
+// the error code and message. col is -1 if the error has no column.
+func (ep *ExcelParser) cellErrorInfo(rowIndex, col int, column, field, value string) ErrorInfo {
+	ei := ErrorInfo{
+		Row:    rowIndex,
+		Col:    col,
+		Column: column,
+		Sheet:  ep.sheetName,
+		Value:  value,
+		Field:  field,
+	}
+	if col >= 0 {
+		ei.Cell, _ = excelize.CoordinatesToCellName(col+1, rowIndex)
 	}
+	return ei
+}
 
-	return titleMap, nil
+// recordError collects a row error. With workers the errors are sent to errChan
+// and collected by AppendErrors.
+func (ep *ExcelParser) recordError(ei ErrorInfo) {
+	if ep.workers == 0 {
+		*ep.RowErrs = append(*ep.RowErrs, ei)
+	} else {
+		ep.errChan <- ei
+	}
 }
 
 func (ep *ExcelParser) AppendErrors(ctx context.Context, wg *sync.WaitGroup) {
@@ -1,12 +1,18 @@
 package excel2struct
 
 import (
+	"database/sql"
+	"encoding"
 	"errors"
+	"fmt"
+	"reflect"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/lisongxi/excel2struct/utils"
 	"github.com/shopspring/decimal"
+	"github.com/xuri/excelize/v2"
 )
 
 var (
@@ -17,14 +23,35 @@ var (
 		"int16":    FieldParserInt16,
 		"int32":    FieldParserInt32,
 		"int64":    FieldParserInt64,
+		"uint":     FieldParserUint,
+		"uint8":    FieldParserUint8,
+		"uint16":   FieldParserUint16,
+		"uint32":   FieldParserUint32,
+		"uint64":   FieldParserUint64,
 		"float32":  FieldParserFloat32,
 		"float64":  FieldParserFloat64,
+		"Decimal":  FieldParserDecimal,
 		"bool":     FieldParserBool,
 		"Time":     FieldParserTime,
 		"unixNano": FieldParserTimeUnixNano,
+		"Duration": FieldParserDuration,
+	}
+
+	// builtinTypes are the types parsed and converted by default by the name of
+	// their type. Other types with the same name are not sent to them.
+	builtinTypes = map[string]reflect.Type{
+		"Decimal":  decimalType,
+		"Duration": durationType,
 	}
 )
 
+// otherBuiltinType reports whether fieldType is named like a type of builtinTypes
+// without being it, e.g. a user type named Duration.
+func otherBuiltinType(fieldType reflect.Type) bool {
+	builtin, ok := builtinTypes[fieldType.Name()]
+	return ok && builtin != fieldType
+}
+
 var timeLayouts = []string{
 	"2006-01-02 15:04:05",
 	time.RFC3339,
@@ -70,81 +97,166 @@ var timeLayouts = []string{
 
 type FieldParser func(field string) (interface{}, error)
 
+var (
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	scannerType         = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+)
+
+// textUnmarshalerParser decodes the field with the UnmarshalText method of fieldType.
+func textUnmarshalerParser(fieldType reflect.Type) FieldParser {
+	return func(field string) (interface{}, error) {
+		ptr := reflect.New(fieldType)
+		if err := ptr.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(field)); err != nil {
+			return nil, err
+		}
+		return ptr.Elem().Interface(), nil
+	}
+}
+
+// scannerParser decodes the field with the Scan method of fieldType.
+func scannerParser(fieldType reflect.Type) FieldParser {
+	return func(field string) (interface{}, error) {
+		ptr := reflect.New(fieldType)
+		if err := ptr.Interface().(sql.Scanner).Scan(field); err != nil {
+			return nil, err
+		}
+		return ptr.Elem().Interface(), nil
+	}
+}
+
 func FieldParserString(field string) (interface{}, error) {
 	return field, nil
 }
 
 func FieldParserInt(field string) (interface{}, error) {
-	if len(field) == 0 {
-		return int(0), nil
-	}
-	if strings.Contains(field, ".") {
-		f64, err := strconv.ParseFloat(field, 64)
-		if err != nil {
-			return int(0), err
-		}
-		return int(f64), nil
-	}
-	return strconv.Atoi(field)
+	i64, err := parseInt(field, strconv.IntSize, false)
+	return int(i64), err
 }
 
 func FieldParserInt8(field string) (interface{}, error) {
-	if len(field) == 0 {
-		return int8(0), nil
-	}
-	if strings.Contains(field, ".") {
-		f64, err := strconv.ParseFloat(field, 64)
-		if err != nil {
-			return int8(0), err
-		}
-		return int8(f64), nil
-	}
-	parseInt, err := strconv.ParseInt(field, 10, 64)
-	return int8(parseInt), err
+	i64, err := parseInt(field, 8, false)
+	return int8(i64), err
 }
 
 func FieldParserInt16(field string) (interface{}, error) {
+	i64, err := parseInt(field, 16, false)
+	return int16(i64), err
+}
+
+func FieldParserInt32(field string) (interface{}, error) {
+	i64, err := parseInt(field, 32, false)
+	return int32(i64), err
+}
+
+func FieldParserInt64(field string) (interface{}, error) {
+	return parseInt(field, 64, false)
+}
+
+func FieldParserUint(field string) (interface{}, error) {
+	u64, err := parseUint(field, strconv.IntSize, false)
+	return uint(u64), err
+}
+
+func FieldParserUint8(field string) (interface{}, error) {
+	u64, err := parseUint(field, 8, false)
+	return uint8(u64), err
+}
+
+func FieldParserUint16(field string) (interface{}, error) {
+	u64, err := parseUint(field, 16, false)
+	return uint16(u64), err
+}
+
+func FieldParserUint32(field string) (interface{}, error) {
+	u64, err := parseUint(field, 32, false)
+	return uint32(u64), err
+}
+
+func FieldParserUint64(field string) (interface{}, error) {
+	return parseUint(field, 64, false)
+}
+
+// strictIntegerParsers replace the integer parsers with WithStrictInteger,
+// they reject fractional values instead of truncating them.
+var strictIntegerParsers = map[string]FieldParser{
+	"int": func(field string) (interface{}, error) {
+		i64, err := parseInt(field, strconv.IntSize, true)
+		return int(i64), err
+	},
+	"int8": func(field string) (interface{}, error) {
+		i64, err := parseInt(field, 8, true)
+		return int8(i64), err
+	},
+	"int16": func(field string) (interface{}, error) {
+		i64, err := parseInt(field, 16, true)
+		return int16(i64), err
+	},
+	"int32": func(field string) (interface{}, error) {
+		i64, err := parseInt(field, 32, true)
+		return int32(i64), err
+	},
+	"int64": func(field string) (interface{}, error) {
+		return parseInt(field, 64, true)
+	},
+	"uint": func(field string) (interface{}, error) {
+		u64, err := parseUint(field, strconv.IntSize, true)
+		return uint(u64), err
+	},
+	"uint8": func(field string) (interface{}, error) {
+		u64, err := parseUint(field, 8, true)
+		return uint8(u64), err
+	},
+	"uint16": func(field string) (interface{}, error) {
+		u64, err := parseUint(field, 16, true)
+		return uint16(u64), err
+	},
+	"uint32": func(field string) (interface{}, error) {
+		u64, err := parseUint(field, 32, true)
+		return uint32(u64), err
+	},
+	"uint64": func(field string) (interface{}, error) {
+		return parseUint(field, 64, true)
+	},
+}
+
+// parseInt parses an integer of bitSize bits and reports values out of its range.
+// The fraction of a decimal is truncated, or rejected when strict is set.
+func parseInt(field string, bitSize int, strict bool) (int64, error) {
 	if len(field) == 0 {
-		return int16(0), nil
+		return 0, nil
 	}
-	if strings.Contains(field, ".") {
-		f64, err := strconv.ParseFloat(field, 64)
-		if err != nil {
-			return int16(0), err
-		}
-		return int16(f64), nil
+	field, err := integerPart(field, strict)
+	if err != nil {
+		return 0, err
 	}
-	parseInt, err := strconv.ParseInt(field, 10, 64)
-	return int16(parseInt), err
+	return strconv.ParseInt(field, 10, bitSize)
 }
 
-func FieldParserInt32(field string) (interface{}, error) {
+// parseUint parses an unsigned integer of bitSize bits and reports values out of its range.
+// The fraction of a decimal is truncated, or rejected when strict is set.
+func parseUint(field string, bitSize int, strict bool) (uint64, error) {
 	if len(field) == 0 {
-		return int32(0), nil
+		return 0, nil
 	}
-	if strings.Contains(field, ".") {
-		f64, err := strconv.ParseFloat(field, 64)
-		if err != nil {
-			return int32(0), err
-		}
-		return int32(f64), nil
+	field, err := integerPart(field, strict)
+	if err != nil {
+		return 0, err
 	}
-	parseInt, err := strconv.ParseInt(field, 10, 64)
-	return int32(parseInt), err
+	return strconv.ParseUint(field, 10, bitSize)
 }
 
-func FieldParserInt64(field string) (interface{}, error) {
-	if len(field) == 0 {
-		return int64(0), nil
+func integerPart(field string, strict bool) (string, error) {
+	if !strings.Contains(field, ".") {
+		return field, nil
 	}
-	if strings.Contains(field, ".") {
-		f64, err := strconv.ParseFloat(field, 64)
-		if err != nil {
-			return int64(0), err
-		}
-		return int64(f64), nil
+	d, err := decimal.NewFromString(field)
+	if err != nil {
+		return field, err
 	}
-	return strconv.ParseInt(field, 10, 64)
+	if strict && !d.IsInteger() {
+		return field, fmt.Errorf("fractional value %s for an integer field", field)
+	}
+	return d.Truncate(0).String(), nil
 }
 
 func FieldParserFloat32(field string) (interface{}, error) {
@@ -155,7 +267,7 @@ func FieldParserFloat32(field string) (interface{}, error) {
 	if err != nil {
 		return float32(0.00), err
 	}
-	return float32(d.Round(2).InexactFloat64()), nil
+	return float32(d.InexactFloat64()), nil
 }
 
 func FieldParserFloat64(field string) (interface{}, error) {
@@ -166,38 +278,221 @@ func FieldParserFloat64(field string) (interface{}, error) {
 	if err != nil {
 		return 0.00, err
 	}
-	return d.Round(2).InexactFloat64(), nil
+	return d.InexactFloat64(), nil
+}
+
+func FieldParserDecimal(field string) (interface{}, error) {
+	if len(field) == 0 {
+		return decimal.Zero, nil
+	}
+	return decimal.NewFromString(field)
+}
+
+// roundParser rounds the floats and decimals returned by parser to precision decimal places.
+func roundParser(parser FieldParser, precision int) FieldParser {
+	return func(field string) (interface{}, error) {
+		value, err := parser(field)
+		if err != nil {
+			return value, err
+		}
+		switch v := value.(type) {
+		case float32:
+			return float32(decimal.NewFromFloat32(v).Round(int32(precision)).InexactFloat64()), nil
+		case float64:
+			return decimal.NewFromFloat(v).Round(int32(precision)).InexactFloat64(), nil
+		case decimal.Decimal:
+			return v.Round(int32(precision)), nil
+		}
+		return value, nil
+	}
+}
+
+// BoolValues is the vocabulary of the values read as true and false, compared case-insensitively.
+// When writing, the first value of each list is used.
+type BoolValues struct {
+	True  []string
+	False []string
+}
+
+var DefaultBoolValues = BoolValues{
+	True:  []string{"true", "t", "1", "yes", "y", "on", "是", "对", "✓", "√", "启用", "开启"},
+	False: []string{"false", "f", "0", "no", "n", "off", "否", "错", "✗", "×", "停用", "禁用", "关闭"},
+}
+
+// Parse looks up field in the vocabulary.
+func (bv BoolValues) Parse(field string) (bool, error) {
+	field = strings.TrimSpace(field)
+	for _, v := range bv.True {
+		if strings.EqualFold(field, v) {
+			return true, nil
+		}
+	}
+	for _, v := range bv.False {
+		if strings.EqualFold(field, v) {
+			return false, nil
+		}
+	}
+	return false, fmt.Errorf("invalid bool value %q", field)
+}
+
+// Format returns the first true or false value of the vocabulary.
+func (bv BoolValues) Format(b bool) interface{} {
+	if b && len(bv.True) > 0 {
+		return bv.True[0]
+	}
+	if !b && len(bv.False) > 0 {
+		return bv.False[0]
+	}
+	return b
+}
+
+// parseBoolTag parses a `bool` tag such as "启用|停用" or "Y,yes|N,no".
+func parseBoolTag(tag string) (BoolValues, error) {
+	trueValues, falseValues, ok := strings.Cut(tag, "|")
+	if !ok || strings.TrimSpace(trueValues) == "" || strings.TrimSpace(falseValues) == "" {
+		return BoolValues{}, fmt.Errorf("bool tag must look like \"true values|false values\": %s", tag)
+	}
+	split := func(values string) []string {
+		list := strings.Split(values, ",")
+		for i := range list {
+			list[i] = strings.TrimSpace(list[i])
+		}
+		return list
+	}
+	return BoolValues{True: split(trueValues), False: split(falseValues)}, nil
 }
 
 func FieldParserBool(field string) (interface{}, error) {
 	if len(field) == 0 {
 		return false, nil
 	}
-	return strconv.ParseBool(field)
+	return DefaultBoolValues.Parse(field)
+}
+
+// newBoolParser builds a parser like FieldParserBool with its own vocabulary.
+func newBoolParser(values BoolValues) FieldParser {
+	return func(field string) (interface{}, error) {
+		if len(field) == 0 {
+			return false, nil
+		}
+		return values.Parse(field)
+	}
 }
 
 func FieldParserTime(field string) (interface{}, error) {
 	if len(field) == 0 {
 		return time.Time{}, nil
 	}
-	for _, layout := range timeLayouts {
-		t, err := time.Parse(layout, field)
+	return parseTime(field, timeLayouts, time.UTC, false, false)
+}
+
+func FieldParserTimeUnixNano(field string) (interface{}, error) {
+	if len(field) == 0 {
+		return int64(0), nil
+	}
+	t, err := parseTime(field, timeLayouts, time.UTC, false, false)
+	if err != nil {
+		return int64(0), errors.New("field time UNIX Nano format error")
+	}
+	return t.UnixNano(), nil
+}
+
+// timeParsers are the built-in parsers replaced by newTimeParser when a time field
+// has its own layout, location or date system, or reads raw cell values. The value tells whether the parser
+// returns a unix nano timestamp.
+var timeParsers = map[string]bool{
+	"Time":     false,
+	"unixNano": true,
+}
+
+// sameParser reports whether a and b are the same function.
+func sameParser(a, b FieldParser) bool {
+	return a != nil && b != nil && reflect.ValueOf(a).Pointer() == reflect.ValueOf(b).Pointer()
+}
+
+// newTimeParser builds a parser like FieldParserTime or FieldParserTimeUnixNano
+// that tries the given layouts in loc, and reads serial date numbers in the 1904
+// date system if date1904 is set. With raw, the numbers of raw cell values are all
+// read as serial dates.
+func newTimeParser(unixNano bool, layouts []string, loc *time.Location, date1904, raw bool) FieldParser {
+	if unixNano {
+		return func(field string) (interface{}, error) {
+			if len(field) == 0 {
+				return int64(0), nil
+			}
+			t, err := parseTime(field, layouts, loc, date1904, raw)
+			if err != nil {
+				return int64(0), errors.New("field time UNIX Nano format error")
+			}
+			return t.UnixNano(), nil
+		}
+	}
+	return func(field string) (interface{}, error) {
+		if len(field) == 0 {
+			return time.Time{}, nil
+		}
+		return parseTime(field, layouts, loc, date1904, raw)
+	}
+}
+
+// parseTime parses a time string in one of layouts, or else an Excel serial date
+// number, read as wall clock in loc.
+func parseTime(field string, layouts []string, loc *time.Location, date1904, raw bool) (time.Time, error) {
+	for _, layout := range layouts {
+		t, err := time.ParseInLocation(layout, field, loc)
 		if err == nil {
 			return t, nil
 		}
 	}
+	if isSerialDate(field, raw) {
+		serial, err := strconv.ParseFloat(field, 64)
+		if err != nil {
+			return time.Time{}, err
+		}
+		t, err := excelize.ExcelDateToTime(serial, date1904)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc), nil
+	}
 	return time.Time{}, errors.New("field time format error")
 }
 
-func FieldParserTimeUnixNano(field string) (interface{}, error) {
+// isSerialDate reports whether field is an Excel serial date number, e.g. "45321" or "45321.5".
+// Numbers with more than 5 integer digits are left to layouts such as "20060102".
+// Unless field is a raw cell value, smaller numbers than 10000 (1927-05-18) are
+// rather years or counts, such as "2024", and are not dates either.
+func isSerialDate(field string, raw bool) bool {
+	if !utils.IsNumber(field) || strings.HasPrefix(field, "-") {
+		return false
+	}
+	integer, _, _ := strings.Cut(field, ".")
+	return len(integer) <= 5 && (raw || len(integer) == 5)
+}
+
+// FieldParserDuration parses a Go duration such as "1h30m", or a clock duration such as "1:30:00" or "90:00".
+func FieldParserDuration(field string) (interface{}, error) {
 	if len(field) == 0 {
-		return int64(0), nil
+		return time.Duration(0), nil
 	}
-	for _, layout := range timeLayouts {
-		t, err := time.Parse(layout, field)
-		if err == nil {
-			return t.UnixNano(), nil
+	if d, err := time.ParseDuration(field); err == nil {
+		return d, nil
+	}
+	parts := strings.Split(field, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return time.Duration(0), errors.New("field duration format error")
+	}
+	units := []time.Duration{time.Minute, time.Second}
+	if len(parts) == 3 {
+		units = []time.Duration{time.Hour, time.Minute, time.Second}
+	}
+	var d time.Duration
+	for i, part := range parts {
+		n, err := strconv.ParseFloat(part, 64)
+		if err != nil || n < 0 {
+			return time.Duration(0), errors.New("field duration format error")
 		}
+		d += time.Duration(n * float64(units[i]))
 	}
-	return int64(0), errors.New("field time UNIX Nano format error")
+	return d, nil
 }
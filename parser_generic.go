@@ -0,0 +1,25 @@
+package excel2struct
+
+import (
+	"context"
+	"io"
+)
+
+// ParseAs parses rows into a slice of *T. T must be a struct type carrying the
+// usual `excel`, `parser`, `eIndex` and `default` tags.
+// The row errors collected during this call are returned with the result
+// instead of being stored in ep.RowErrs, after the warnings of the title row
+// such as missing optional columns.
+func ParseAs[T any](ctx context.Context, ep *ExcelParser, rows [][]string, skip bool) ([]*T, []ErrorInfo, error) {
+	output := make([]*T, 0)
+	result, err := ep.ParseWithResult(ctx, rows, &output, skip)
+	return output, result.errorInfos(), err
+}
+
+// ReadAs reads the configured sheet from reader and parses it into a slice of *T.
+// args are passed through to Reader, e.g. the encoding of a xls file.
+func ReadAs[T any](ctx context.Context, ep *ExcelParser, reader io.ReadSeeker, skip bool, args ...interface{}) ([]*T, []ErrorInfo, error) {
+	output := make([]*T, 0)
+	result, err := ep.ReaderWithResult(ctx, reader, &output, skip, args...)
+	return output, result.errorInfos(), err
+}
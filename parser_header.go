@@ -0,0 +1,294 @@
+package excel2struct
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// HeaderMatch relaxes how excel titles are compared with the column names of the
+// `excel` tag. Titles are always trimmed, the flags can be combined.
+type HeaderMatch int
+
+const (
+	HeaderIgnoreCase  HeaderMatch = 1 << iota // "Name" matches "name"
+	HeaderIgnoreWidth                         // full-width letters, digits and spaces match their half-width forms
+	HeaderIgnoreSpace                         // spaces inside titles are ignored, "Full Name" matches "FullName"
+)
+
+// normalize returns the form of title that is compared with the column names.
+func (hm HeaderMatch) normalize(title string) string {
+	title = strings.TrimSpace(title)
+	if hm&HeaderIgnoreWidth != 0 {
+		title = strings.Map(func(r rune) rune {
+			switch {
+			case r == '　':
+				return ' '
+			case r >= '！' && r <= '～':
+				return r - 0xfee0
+			}
+			return r
+		}, title)
+	}
+	if hm&HeaderIgnoreSpace != 0 {
+		title = strings.Map(func(r rune) rune {
+			if unicode.IsSpace(r) {
+				return -1
+			}
+			return r
+		}, title)
+	}
+	if hm&HeaderIgnoreCase != 0 {
+		title = strings.ToLower(title)
+	}
+	return title
+}
+
+// MatchRule tells how a struct field was matched with its column.
+type MatchRule string
+
+const (
+	MatchExact  MatchRule = "exact"  // the title is the column name or one of its aliases
+	MatchEIndex MatchRule = "eIndex" // the column is set by the eIndex tag
+	MatchRegex  MatchRule = "regex"  // the title matches the excelRegex tag
+	MatchFuzzy  MatchRule = "fuzzy"  // the title is within the edit distance set by WithFuzzyHeaders
+)
+
+// FieldMatch is the column a struct field was matched with.
+type FieldMatch struct {
+	Field  string // struct field name
+	Column string // title of the column
+	Index  int    // column index, counting from 0
+	Rule   MatchRule
+}
+
+// ColumnTitle is a column of the title row.
+type ColumnTitle struct {
+	Index int // column index, counting from 0
+	Title string
+}
+
+// HeaderMatchReport tells which column every struct field was matched with and
+// which columns were left unused, e.g. to check the files uploaded by vendors.
+type HeaderMatchReport struct {
+	Fields []FieldMatch  // matched fields in column order
+	Unused []ColumnTitle // titled columns matched by no field
+}
+
+// parseTitle maps the key of every struct field to the index of its column and
+// keeps a report of the matches in ep.headerReport. Fields without a column are
+// left out of the map and reported in ep.warnings, unless they are required or
+// WithStrictColumns is set. The unused columns are collected by the extra field,
+// or rejected with WithDisallowUnknownColumns if there is none. The rows are
+// parsed field by field in the column order kept in ep.fieldOrder.
+// Fields are first matched with the columns titled with one of their aliases, the
+// fields left are then matched by their excelRegex tag and, with WithFuzzyHeaders,
+// by the edit distance to their aliases, both only with the columns left unused.
+// If columns with different titles match a field without an eIndex tag the match is
+// ambiguous. Columns with the same title are matched by the first of them, unless
+// the field sets an eIndex tag.
+func (ep *ExcelParser) parseTitle(row []string, structFieldMetaMap map[string]FieldMetadata) (map[string]int, error) {
+	columns := make(map[string][]int)
+	for idx, title := range row {
+		key := ep.headerMatch.normalize(title)
+		columns[key] = append(columns[key], idx)
+	}
+
+	var extra bool
+	fields := make([]string, 0, len(structFieldMetaMap))
+	for field, fieldMeta := range structFieldMetaMap {
+		if fieldMeta.EIndex > len(row) {
+			ei := ep.cellErrorInfo(ep.headerIndex+1, -1, field, fieldMeta.FName, "")
+			ei.ErrorCode = ERROR_EINDEX_EXCEED
+			ei.ErrorMsg = fmt.Sprintf(ERROR_TYPE[ERROR_EINDEX_EXCEED], fieldMeta.FName)
+			return nil, CellError{ErrorInfo: ei}
+		}
+		if fieldMeta.Extra {
+			extra = true
+			continue
+		}
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	titleMap := make(map[string]int, len(structFieldMetaMap))
+	rules := make(map[string]MatchRule, len(structFieldMetaMap))
+	used := make(map[int]bool, len(row))
+	match := func(field string, candidates []int, rule MatchRule) error {
+		matched, err := matchColumn(structFieldMetaMap[field], row, candidates)
+		if err != nil || matched < 0 {
+			return err
+		}
+		titleMap[field], rules[field], used[matched] = matched, rule, true
+		return nil
+	}
+
+	for _, field := range fields {
+		var candidates []int
+		for _, alias := range structFieldMetaMap[field].Aliases {
+			candidates = append(candidates, columns[ep.headerMatch.normalize(alias)]...)
+		}
+		if err := match(field, candidates, MatchExact); err != nil {
+			return nil, err
+		}
+	}
+	for _, field := range fields {
+		regex := structFieldMetaMap[field].regex
+		if _, ok := titleMap[field]; ok || regex == nil {
+			continue
+		}
+		var candidates []int
+		for idx, title := range row {
+			if !used[idx] && regex.MatchString(strings.TrimSpace(title)) {
+				candidates = append(candidates, idx)
+			}
+		}
+		if err := match(field, candidates, MatchRegex); err != nil {
+			return nil, err
+		}
+	}
+	for _, field := range fields {
+		if _, ok := titleMap[field]; ok || ep.fuzzyDistance <= 0 {
+			continue
+		}
+		if err := match(field, ep.fuzzyColumns(structFieldMetaMap[field], row, used), MatchFuzzy); err != nil {
+			return nil, err
+		}
+	}
+
+	report := &HeaderMatchReport{}
+	for _, field := range fields {
+		fieldMeta := structFieldMetaMap[field]
+		matched, ok := titleMap[field]
+		if !ok {
+			ei := ep.cellErrorInfo(ep.headerIndex+1, -1, field, fieldMeta.FName, "")
+			ei.ErrorCode = ERROR_FIELD_MATCH
+			ei.ErrorMsg = fmt.Sprintf(ERROR_TYPE[ERROR_FIELD_MATCH], field)
+			if fieldMeta.Required {
+				ei.ErrorMsg = fmt.Sprintf("required field '%s' not found in excel title row", field)
+				return nil, CellError{ErrorInfo: ei}
+			}
+			if ep.strictColumns {
+				return nil, CellError{ErrorInfo: ei}
+			}
+			// the cells of a missing column are parsed as empty, the column is reported once
+			ep.warnings = append(ep.warnings, ei)
+			continue
+		}
+		if fieldMeta.EIndex > 0 {
+			delete(used, matched)
+			matched = fieldMeta.EIndex - 1
+			titleMap[field], rules[field] = matched, MatchEIndex
+		}
+		report.Fields = append(report.Fields, FieldMatch{
+			Field:  fieldMeta.FName,
+			Column: strings.TrimSpace(row[matched]),
+			Index:  matched,
+			Rule:   rules[field],
+		})
+	}
+	sort.SliceStable(report.Fields, func(i, j int) bool { return report.Fields[i].Index < report.Fields[j].Index })
+	for _, fieldMatch := range report.Fields {
+		used[fieldMatch.Index] = true
+	}
+	for idx, title := range row {
+		if title = strings.TrimSpace(title); title != "" && !used[idx] {
+			report.Unused = append(report.Unused, ColumnTitle{Index: idx, Title: title})
+		}
+	}
+	if ep.disallowUnknown && !extra && len(report.Unused) > 0 {
+		titles := make([]string, 0, len(report.Unused))
+		for _, column := range report.Unused {
+			titles = append(titles, "["+column.Title+"]")
+		}
+		return nil, fmt.Errorf(ERROR_TYPE[ERROR_UNKNOWN_TITLE], strings.Join(titles, ", "))
+	}
+	ep.headerReport = report
+
+	ep.fieldOrder = make([]string, 0, len(structFieldMetaMap))
+	for field, fieldMeta := range structFieldMetaMap {
+		if !fieldMeta.Extra {
+			ep.fieldOrder = append(ep.fieldOrder, field)
+		}
+	}
+	sort.Slice(ep.fieldOrder, func(i, j int) bool {
+		a, aOk := titleMap[ep.fieldOrder[i]]
+		b, bOk := titleMap[ep.fieldOrder[j]]
+		if aOk != bOk {
+			return aOk
+		}
+		if a != b {
+			return a < b
+		}
+		return ep.fieldOrder[i] < ep.fieldOrder[j]
+	})
+	if extra {
+		ep.fieldOrder = append(ep.fieldOrder, "")
+	}
+
+	return titleMap, nil
+}
+
+// matchColumn returns the first of the candidate columns, or -1 if there is none.
+// It fails if the candidates have different titles and the field sets no eIndex tag.
+func matchColumn(fieldMeta FieldMetadata, row []string, candidates []int) (int, error) {
+	matched := -1
+	for _, idx := range candidates {
+		if matched >= 0 && fieldMeta.EIndex == 0 && strings.TrimSpace(row[idx]) != strings.TrimSpace(row[matched]) {
+			return -1, fmt.Errorf(ERROR_TYPE[ERROR_AMBIGUOUS], fieldMeta.FName, strings.TrimSpace(row[matched]), strings.TrimSpace(row[idx]))
+		}
+		if matched < 0 || idx < matched {
+			matched = idx
+		}
+	}
+	return matched, nil
+}
+
+// fuzzyColumns returns the unused columns whose titles are closest to an alias
+// of the field, within the edit distance set by WithFuzzyHeaders.
+func (ep *ExcelParser) fuzzyColumns(fieldMeta FieldMetadata, row []string, used map[int]bool) []int {
+	var candidates []int
+	best := ep.fuzzyDistance + 1
+	for idx, title := range row {
+		title = ep.headerMatch.normalize(title)
+		if used[idx] || title == "" {
+			continue
+		}
+		for _, alias := range fieldMeta.Aliases {
+			distance := levenshtein(title, ep.headerMatch.normalize(alias))
+			if distance > ep.fuzzyDistance {
+				continue
+			}
+			switch {
+			case distance < best:
+				best, candidates = distance, []int{idx}
+			case distance == best && (len(candidates) == 0 || candidates[len(candidates)-1] != idx):
+				candidates = append(candidates, idx)
+			}
+		}
+	}
+	return candidates
+}
+
+// levenshtein returns the edit distance between a and b, counted in runes.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
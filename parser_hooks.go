@@ -0,0 +1,86 @@
+package excel2struct
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// BeforeRowHook is implemented by row structs that want to see, or clean up, the
+// cells of a row before they are parsed. Returning an error rejects the row.
+type BeforeRowHook interface {
+	BeforeRow(ctx context.Context, row []string) error
+}
+
+// AfterParseHook is implemented by row structs that want to be called once all
+// their fields are set, e.g. to compute fields. Returning an error rejects the row.
+type AfterParseHook interface {
+	AfterParse(ctx context.Context) error
+}
+
+// RowValidator is implemented by row structs checking their fields against each
+// other, e.g. that the end date is after the start date. It is called after
+// AfterParse, any returned FieldError rejects the row.
+type RowValidator interface {
+	ValidateRow() []FieldError
+}
+
+// FieldError is an error of a column found by RowValidator.
+type FieldError struct {
+	Column string // excel column name
+	Msg    string
+}
+
+// beforeRow calls the BeforeRow hook of out. It reports whether the row is
+// rejected, and returns an error if the row can not be skipped.
+func (ep *ExcelParser) beforeRow(ctx context.Context, rowIndex int, row []string, out reflect.Value, skip bool) (bool, error) {
+	hook, ok := out.Interface().(BeforeRowHook)
+	if !ok {
+		return false, nil
+	}
+	if err := hook.BeforeRow(ctx, row); err != nil {
+		return true, ep.rowHookError(ep.cellErrorInfo(rowIndex, -1, "", "", ""), "BeforeRow", err, skip)
+	}
+	return false, nil
+}
+
+// afterRow calls the AfterParse and ValidateRow hooks of out. It reports whether
+// the row is rejected, and returns an error if the row can not be skipped.
+func (ep *ExcelParser) afterRow(ctx context.Context, rowIndex int, structFieldMetaMap map[string]FieldMetadata, row []string, titleMap map[string]int, out reflect.Value, skip bool) (bool, error) {
+	if hook, ok := out.Interface().(AfterParseHook); ok {
+		if err := hook.AfterParse(ctx); err != nil {
+			return true, ep.rowHookError(ep.cellErrorInfo(rowIndex, -1, "", "", ""), "AfterParse", err, skip)
+		}
+	}
+	validator, ok := out.Interface().(RowValidator)
+	if !ok {
+		return false, nil
+	}
+	fieldErrs := validator.ValidateRow()
+	for _, fieldErr := range fieldErrs {
+		var value string
+		col, ok := titleMap[fieldErr.Column]
+		if !ok {
+			col = -1
+		} else if col < len(row) {
+			value = row[col]
+		}
+		ei := ep.cellErrorInfo(rowIndex, col, fieldErr.Column, structFieldMetaMap[fieldErr.Column].FName, value)
+		if err := ep.rowHookError(ei, "ValidateRow", errors.New(fieldErr.Msg), skip); err != nil {
+			return true, err
+		}
+	}
+	return len(fieldErrs) > 0, nil
+}
+
+// rowHookError records the error of a row hook in ei, or returns it if the row can not be skipped.
+func (ep *ExcelParser) rowHookError(ei ErrorInfo, hook string, err error, skip bool) error {
+	ei.ErrorCode = ERROR_ROW
+	ei.ErrorMsg = fmt.Sprintf(ERROR_TYPE[ERROR_ROW], hook, ei.Row, err)
+	if !skip {
+		return CellError{ErrorInfo: ei, Err: err}
+	}
+	ep.recordError(ei)
+	return nil
+}
@@ -0,0 +1,101 @@
+package excel2struct
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// NumberFormat describes how numbers are written in a sheet, so that values like
+// "1.234,56", "¥1,200", "12.5%" or "(300)" can be read by the numeric parsers.
+type NumberFormat struct {
+	DecimalSeparator   string   // "." if empty
+	ThousandsSeparator string   // removed from the value, e.g. ","
+	CurrencySymbols    []string // removed from the value, e.g. "¥"
+	PercentAsFraction  bool     // "12.5%" is read as 0.125 instead of 12.5
+}
+
+var defaultCurrencySymbols = []string{"¥", "￥", "$", "€", "£", "CNY", "RMB", "USD", "EUR"}
+
+// NumberLocales holds the number formats the `number` tag can refer to, e.g. `number:"de"`.
+var NumberLocales = map[string]NumberFormat{
+	"en": {DecimalSeparator: ".", ThousandsSeparator: ",", CurrencySymbols: defaultCurrencySymbols, PercentAsFraction: true},
+	"zh": {DecimalSeparator: ".", ThousandsSeparator: ",", CurrencySymbols: defaultCurrencySymbols, PercentAsFraction: true},
+	"de": {DecimalSeparator: ",", ThousandsSeparator: ".", CurrencySymbols: defaultCurrencySymbols, PercentAsFraction: true},
+	"fr": {DecimalSeparator: ",", ThousandsSeparator: " ", CurrencySymbols: defaultCurrencySymbols, PercentAsFraction: true},
+}
+
+// numberParsers are the built-in parsers whose input is normalized by a NumberFormat.
+var numberParsers = map[string]bool{
+	"int": true, "int8": true, "int16": true, "int32": true, "int64": true,
+	"uint": true, "uint8": true, "uint16": true, "uint32": true, "uint64": true,
+	"float32": true, "float64": true, "Decimal": true,
+}
+
+// Normalize rewrites field as a plain number such as "-1234.56".
+func (nf NumberFormat) Normalize(field string) (string, error) {
+	s := strings.TrimSpace(field)
+	if s == "" {
+		return s, nil
+	}
+
+	negative := false
+	if strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") {
+		negative = true
+		s = strings.TrimSpace(s[1 : len(s)-1])
+	}
+	if strings.HasPrefix(s, "-") {
+		negative = !negative
+		s = strings.TrimSpace(s[1:])
+	}
+	s = strings.TrimPrefix(s, "+")
+
+	for _, symbol := range nf.CurrencySymbols {
+		s = strings.ReplaceAll(s, symbol, "")
+	}
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "-") {
+		// e.g. "¥-1,200"
+		negative = !negative
+		s = s[1:]
+	}
+
+	percent := strings.HasSuffix(s, "%")
+	s = strings.TrimSpace(strings.TrimSuffix(s, "%"))
+
+	if nf.ThousandsSeparator != "" {
+		s = strings.ReplaceAll(s, nf.ThousandsSeparator, "")
+	}
+	if strings.TrimSpace(nf.ThousandsSeparator) == "" {
+		// a blank separator also stands for the other blanks used to group digits
+		s = strings.NewReplacer(" ", "", "\u00a0", "", "\u202f", "").Replace(s)
+	}
+	if nf.DecimalSeparator != "" && nf.DecimalSeparator != "." {
+		s = strings.ReplaceAll(s, nf.DecimalSeparator, ".")
+	}
+
+	d, err := decimal.NewFromString(s)
+	if err != nil {
+		return field, fmt.Errorf("invalid number %q", field)
+	}
+	if percent && nf.PercentAsFraction {
+		d = d.Shift(-2)
+	}
+	if negative {
+		d = d.Neg()
+	}
+	return d.String(), nil
+}
+
+// numberParser normalizes the field with nf before handing it to parser.
+func numberParser(parser FieldParser, nf NumberFormat) FieldParser {
+	return func(field string) (interface{}, error) {
+		normalized, err := nf.Normalize(field)
+		if err != nil {
+			value, _ := parser("")
+			return value, err
+		}
+		return parser(normalized)
+	}
+}
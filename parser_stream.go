@@ -0,0 +1,113 @@
+package excel2struct
+
+import (
+	"context"
+	"errors"
+	"io"
+	"reflect"
+)
+
+// StreamAs reads the configured sheet row by row and calls fn with every parsed row,
+// so the memory used does not grow with the size of the file.
+// Parsing stops as soon as fn returns an error, which is then returned.
+// Rows are always parsed in order, the WithWorkers option is ignored.
+// The warnings of the title row are returned before the row errors, as in ParseAs.
+func StreamAs[T any](ctx context.Context, ep *ExcelParser, reader io.ReadSeeker, skip bool, fn func(*T) error, args ...interface{}) ([]ErrorInfo, error) {
+	call := ep.session()
+	call.workers = 0
+	structType := reflect.TypeOf((*T)(nil)).Elem()
+	err := call.stream(ctx, reader, structType, skip, func(out reflect.Value) error {
+		return fn(out.Interface().(*T))
+	}, args...)
+	return call.result().errorInfos(), err
+}
+
+// BatchAs reads the configured sheet like StreamAs but hands the parsed rows to fn
+// in batches of size, e.g. to insert them into a database chunk by chunk.
+// The next batch is only parsed after fn has returned, and parsing stops as soon
+// as fn returns an error. The last batch may hold fewer than size rows.
+func BatchAs[T any](ctx context.Context, ep *ExcelParser, reader io.ReadSeeker, size int, skip bool, fn func(ctx context.Context, batch []*T) error, args ...interface{}) ([]ErrorInfo, error) {
+	if size <= 0 {
+		return nil, errors.New("batch size must be greater than 0")
+	}
+	batch := make([]*T, 0, size)
+	rowErrs, err := StreamAs(ctx, ep, reader, skip, func(out *T) error {
+		batch = append(batch, out)
+		if len(batch) < size {
+			return nil
+		}
+		full := batch
+		batch = make([]*T, 0, size)
+		return fn(ctx, full)
+	}, args...)
+	if err != nil || len(batch) == 0 {
+		return rowErrs, err
+	}
+	return rowErrs, fn(ctx, batch)
+}
+
+func (ep *ExcelParser) stream(ctx context.Context, reader io.ReadSeeker, structType reflect.Type, skip bool, fn func(out reflect.Value) error, args ...interface{}) (err error) {
+	rows, err := ep.openRows(reader, ep.sheetName, args...)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	structFieldMetaMap, err := ep.parseStructTags(structType)
+	if err != nil {
+		return
+	}
+
+	var (
+		titleMap map[string]int
+		row      []string
+		idx      int
+		empty    int // empty rows are only parsed once a non-empty row follows them, like GetRows
+	)
+	for ; rows.Next(); idx++ {
+		if err = ctx.Err(); err != nil {
+			return
+		}
+		if row, err = rows.Columns(); err != nil {
+			return
+		}
+		if idx < ep.headerIndex {
+			continue
+		}
+		if idx == ep.headerIndex {
+			if titleMap, err = ep.parseTitle(row, structFieldMetaMap); err != nil {
+				return
+			}
+			continue
+		}
+		if len(row) == 0 {
+			empty++
+			continue
+		}
+		for ; empty >= 0; empty-- {
+			var cells []string
+			if empty == 0 {
+				cells = row
+			}
+			out := reflect.New(structType)
+			valid, parseErr := ep.parseRowToStruct(ctx, idx-empty+1, structFieldMetaMap, cells, titleMap, out, skip)
+			if err = parseErr; err != nil {
+				return
+			}
+			if !valid {
+				continue
+			}
+			if err = fn(out); err != nil {
+				return
+			}
+		}
+		empty = 0
+	}
+	if err = rows.Error(); err != nil {
+		return
+	}
+	if idx <= ep.headerIndex {
+		return errors.New("error excel header index")
+	}
+	return
+}
@@ -0,0 +1,1033 @@
+package excel2struct
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/zeebo/assert"
+)
+
+// mustExcelParser creates a xlsx parser with the header in the first row.
+func mustExcelParser(t *testing.T, opts ...Option) *ExcelParser {
+	excelParser, err := NewExcelParser("xlsx", 0, "", opts...)
+	assert.Nil(t, err)
+	return excelParser
+}
+
+type RowStruct struct {
+	Name  string  `excel:"name,required"`
+	Age   int8    `excel:"age,required"`
+	Score float64 `excel:"score"`
+}
+
+func TestParseAs(t *testing.T) {
+	ctx := context.Background()
+
+	rows := [][]string{
+		{"name", "age", "score"},
+		{"Lucas", "18", "92.5"},
+		{"John", "25", "eighty"},
+		{"Mike", "33", ""},
+	}
+
+	excelParser, err := NewExcelParser("xlsx", 0, "")
+	assert.Nil(t, err)
+
+	result, rowErrs, err := ParseAs[RowStruct](ctx, excelParser, rows, true)
+	assert.Nil(t, err)
+	assert.Equal(t, len(result), 3)
+	assert.Equal(t, result[0].Name, "Lucas")
+	assert.Equal(t, result[2].Age, int8(33))
+	assert.Equal(t, len(rowErrs), 1)
+	assert.Equal(t, rowErrs[0].Row, 3)
+	assert.Equal(t, rowErrs[0].ErrorCode, ERROR_PARSE)
+	assert.Equal(t, rowErrs[0].Col, 2)
+	assert.Equal(t, rowErrs[0].Cell, "C3")
+	assert.Equal(t, rowErrs[0].Value, "eighty")
+	assert.Equal(t, rowErrs[0].Field, "Score")
+	assert.Equal(t, len(*excelParser.RowErrs), 0)
+
+	_, _, err = ParseAs[int](ctx, excelParser, rows, true)
+	assert.Error(t, err)
+}
+
+func TestStreamAs(t *testing.T) {
+	ctx := context.Background()
+
+	file := newXlsxReader(t, map[string][][]interface{}{
+		"Sheet1": {
+			{"name", "age", "score"},
+			{"Lucas", 18, 92.5},
+			{},
+			{"Mike", 33, "eighty"},
+		},
+	})
+
+	excelParser, err := NewExcelParser("xlsx", 0, "Sheet1", WithWorkers(2))
+	assert.Nil(t, err)
+
+	var names []string
+	rowErrs, err := StreamAs(ctx, excelParser, file, true, func(rs *RowStruct) error {
+		names = append(names, rs.Name)
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.DeepEqual(t, names, []string{"Lucas", "Mike"})
+	assert.Equal(t, len(rowErrs), 2)
+	assert.Equal(t, rowErrs[1].Row, 4)
+	assert.Equal(t, rowErrs[1].Cell, "C4")
+	assert.Equal(t, rowErrs[1].Sheet, "Sheet1")
+
+	csvParser, err := NewExcelParser("csv", 0, "")
+	assert.Nil(t, err)
+
+	stop := errors.New("stop")
+	_, err = StreamAs(ctx, csvParser, strings.NewReader("name,age,score\nLucas,18,1\nMike,33,2\n"), true, func(rs *RowStruct) error {
+		return stop
+	})
+	assert.Equal(t, err, stop)
+
+	_, err = StreamAs(ctx, csvParser, strings.NewReader(""), true, func(rs *RowStruct) error { return nil })
+	assert.Error(t, err)
+	assert.Equal(t, err.Error(), "error excel header index")
+}
+
+func TestBatchAs(t *testing.T) {
+	ctx := context.Background()
+
+	excelParser, err := NewExcelParser("csv", 0, "")
+	assert.Nil(t, err)
+
+	data := "name,age,score\nA,1,1\nB,2,2\nC,3,3\nD,4,4\nE,5,5\n"
+
+	var sizes []int
+	_, err = BatchAs(ctx, excelParser, strings.NewReader(data), 2, true, func(ctx context.Context, batch []*RowStruct) error {
+		sizes = append(sizes, len(batch))
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.DeepEqual(t, sizes, []int{2, 2, 1})
+
+	stop := errors.New("stop")
+	calls := 0
+	_, err = BatchAs(ctx, excelParser, strings.NewReader(data), 2, true, func(ctx context.Context, batch []*RowStruct) error {
+		calls++
+		return stop
+	})
+	assert.Equal(t, err, stop)
+	assert.Equal(t, calls, 1)
+}
+
+func TestParserReuse(t *testing.T) {
+	ctx := context.Background()
+
+	rows := [][]string{
+		{"name", "age", "score"},
+		{"Lucas", "18", "bad"},
+		{"John", "25", "80"},
+	}
+
+	excelParser, err := NewExcelParser("xlsx", 0, "", WithWorkers(2))
+	assert.Nil(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var output []*RowStruct
+			result, err := excelParser.ParseWithResult(ctx, rows, &output, true)
+			assert.Nil(t, err)
+			assert.Equal(t, len(output), 2)
+			assert.Equal(t, len(result.RowErrs), 1)
+		}()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var output []*RowStruct
+			assert.Nil(t, excelParser.Parse(ctx, rows, &output, true))
+			assert.Equal(t, len(output), 2)
+		}()
+	}
+	wg.Wait()
+
+	for i := 0; i < 2; i++ {
+		var output []*RowStruct
+		assert.Nil(t, excelParser.Parse(ctx, rows, &output, true))
+		assert.Equal(t, len(*excelParser.RowErrs), 1)
+	}
+}
+
+type AuditFields struct {
+	CreatedBy string `excel:"createdBy"`
+}
+
+type Address struct {
+	City string `excel:"City"`
+	Zip  string `excel:"Zip"`
+}
+
+type NestedStruct struct {
+	AuditFields
+	Name     string   `excel:"name,required"`
+	Shipping Address  `excel:"shipping,prefix=Ship "`
+	Billing  *Address `excel:"billing,prefix=Bill "`
+}
+
+func TestParseNested(t *testing.T) {
+	ctx := context.Background()
+
+	rows := [][]string{
+		{"name", "createdBy", "Ship City", "Ship Zip", "Bill City", "Bill Zip"},
+		{"Lucas", "admin", "Shanghai", "200000", "Beijing", "100000"},
+	}
+
+	excelParser, err := NewExcelParser("xlsx", 0, "")
+	assert.Nil(t, err)
+
+	result, rowErrs, err := ParseAs[NestedStruct](ctx, excelParser, rows, false)
+	assert.Nil(t, err)
+	assert.Equal(t, len(rowErrs), 0)
+	assert.Equal(t, len(result), 1)
+	assert.Equal(t, result[0].CreatedBy, "admin")
+	assert.Equal(t, result[0].Shipping.City, "Shanghai")
+	assert.Equal(t, result[0].Billing.Zip, "100000")
+}
+
+type PointerStruct struct {
+	Name     *string    `excel:"name"`
+	Age      *int8      `excel:"age"`
+	Score    *float64   `excel:"score"`
+	Birthday *time.Time `excel:"birthday"`
+	IsStaff  *bool      `excel:"isStaff"`
+}
+
+func TestParsePointer(t *testing.T) {
+	ctx := context.Background()
+
+	rows := [][]string{
+		{"name", "age", "score", "birthday", "isStaff"},
+		{"Lucas", "0", "92.5", "2005-08-17", "false"},
+		{"", "", "", "", ""},
+	}
+
+	excelParser, err := NewExcelParser("xlsx", 0, "")
+	assert.Nil(t, err)
+
+	result, rowErrs, err := ParseAs[PointerStruct](ctx, excelParser, rows, false)
+	assert.Nil(t, err)
+	assert.Equal(t, len(rowErrs), 0)
+	assert.Equal(t, len(result), 2)
+	assert.Equal(t, *result[0].Name, "Lucas")
+	assert.Equal(t, *result[0].Age, int8(0))
+	assert.Equal(t, *result[0].Score, 92.5)
+	assert.Equal(t, result[0].Birthday.Year(), 2005)
+	assert.Equal(t, *result[0].IsStaff, false)
+	assert.Nil(t, result[1].Name)
+	assert.Nil(t, result[1].Age)
+	assert.Nil(t, result[1].Birthday)
+}
+
+type SKU string
+
+func (s *SKU) UnmarshalText(text []byte) error {
+	if !strings.HasPrefix(string(text), "SKU-") {
+		return errors.New("invalid sku")
+	}
+	*s = SKU(strings.TrimPrefix(string(text), "SKU-"))
+	return nil
+}
+
+type Cents int64
+
+func (c *Cents) Scan(src interface{}) error {
+	d, err := decimal.NewFromString(src.(string))
+	if err != nil {
+		return err
+	}
+	*c = Cents(d.Shift(2).IntPart())
+	return nil
+}
+
+type InterfaceStruct struct {
+	SKU   SKU    `excel:"sku,required"`
+	Price *Cents `excel:"price"`
+}
+
+func TestParseInterfaces(t *testing.T) {
+	ctx := context.Background()
+
+	rows := [][]string{
+		{"sku", "price"},
+		{"SKU-001", "12.34"},
+		{"002", ""},
+	}
+
+	excelParser, err := NewExcelParser("xlsx", 0, "")
+	assert.Nil(t, err)
+
+	result, rowErrs, err := ParseAs[InterfaceStruct](ctx, excelParser, rows, true)
+	assert.Nil(t, err)
+	assert.Equal(t, result[0].SKU, SKU("001"))
+	assert.Equal(t, *result[0].Price, Cents(1234))
+	assert.Equal(t, len(rowErrs), 1)
+	assert.Equal(t, rowErrs[0].ErrorCode, ERROR_PARSE)
+}
+
+func TestParseUint(t *testing.T) {
+	ctx := context.Background()
+
+	rows := [][]string{
+		{"price", "total", "quantity", "elapsed"},
+		{"0.0035", "1.5", "12.9", "1:30:00"},
+		{"1", "", "-1", "1h"},
+		{"1", "", "65536", "bad"},
+	}
+
+	excelParser, err := NewExcelParser("xlsx", 0, "")
+	assert.Nil(t, err)
+
+	result, rowErrs, err := ParseAs[AmountStruct](ctx, excelParser, rows, true)
+	assert.Nil(t, err)
+	assert.Equal(t, result[0].Price.String(), "0.0035")
+	assert.Equal(t, result[0].Quantity, uint16(12))
+	assert.Equal(t, result[0].Elapsed, 90*time.Minute)
+	assert.Nil(t, result[1].Total)
+	assert.Equal(t, len(rowErrs), 3)
+}
+
+type PrecisionStruct struct {
+	UnitPrice float64         `excel:"unitPrice"`
+	Amount    float64         `excel:"amount" precision:"2"`
+	Rate      decimal.Decimal `excel:"rate" precision:"-1"`
+}
+
+func TestParsePrecision(t *testing.T) {
+	ctx := context.Background()
+
+	rows := [][]string{
+		{"unitPrice", "amount", "rate"},
+		{"0.0035", "12.345", "0.123456789"},
+	}
+
+	excelParser, err := NewExcelParser("xlsx", 0, "")
+	assert.Nil(t, err)
+
+	result, _, err := ParseAs[PrecisionStruct](ctx, excelParser, rows, true)
+	assert.Nil(t, err)
+	assert.Equal(t, result[0].UnitPrice, 0.0035)
+	assert.Equal(t, result[0].Amount, 12.35)
+	assert.Equal(t, result[0].Rate.String(), "0.123456789")
+
+	excelParser, err = NewExcelParser("xlsx", 0, "", WithPrecision(3))
+	assert.Nil(t, err)
+
+	result, _, err = ParseAs[PrecisionStruct](ctx, excelParser, rows, true)
+	assert.Nil(t, err)
+	assert.Equal(t, result[0].UnitPrice, 0.004)
+	assert.Equal(t, result[0].Amount, 12.35)
+	assert.Equal(t, result[0].Rate.String(), "0.123456789")
+}
+
+type IntegerStruct struct {
+	Small int8   `excel:"small"`
+	Count int    `excel:"count"`
+	Size  uint16 `excel:"size"`
+}
+
+func TestParseInteger(t *testing.T) {
+	ctx := context.Background()
+
+	rows := [][]string{
+		{"small", "count", "size"},
+		{"127", "12.7", "12.0"},
+		{"300", "", "-1"},
+		{"-129", "", "70000"},
+	}
+
+	excelParser, err := NewExcelParser("xlsx", 0, "")
+	assert.Nil(t, err)
+
+	result, rowErrs, err := ParseAs[IntegerStruct](ctx, excelParser, rows, true)
+	assert.Nil(t, err)
+	assert.Equal(t, result[0].Small, int8(127))
+	assert.Equal(t, result[0].Count, 12)
+	assert.Equal(t, result[0].Size, uint16(12))
+	assert.Equal(t, result[1].Small, int8(0))
+	assert.Equal(t, len(rowErrs), 4)
+	for _, rowErr := range rowErrs {
+		assert.Equal(t, rowErr.ErrorCode, ERROR_PARSE)
+	}
+
+	excelParser, err = NewExcelParser("xlsx", 0, "", WithStrictInteger())
+	assert.Nil(t, err)
+
+	result, rowErrs, err = ParseAs[IntegerStruct](ctx, excelParser, rows[:2], true)
+	assert.Nil(t, err)
+	assert.Equal(t, result[0].Count, 0)
+	assert.Equal(t, result[0].Size, uint16(12))
+	assert.Equal(t, len(rowErrs), 1)
+	assert.Equal(t, rowErrs[0].Column, "count")
+
+	answer := func(field string) (interface{}, error) { return 42, nil }
+	excelParser, err = NewExcelParser("xlsx", 0, "", WithFieldParser("int", answer), WithStrictInteger())
+	assert.Nil(t, err)
+
+	result, _, err = ParseAs[IntegerStruct](ctx, excelParser, rows[:2], true)
+	assert.Nil(t, err)
+	assert.Equal(t, result[0].Count, 42)
+}
+
+type DateStruct struct {
+	Date     time.Time `excel:"date"`
+	WhatTime int64     `excel:"whatTime" parser:"unixNano"`
+}
+
+func TestParseSerialDate(t *testing.T) {
+	ctx := context.Background()
+
+	rows := [][]string{
+		{"date", "whatTime"},
+		{"45321", "45321.5"},
+		{"20240130", "2024-01-30 12:00:00"},
+	}
+
+	excelParser, err := NewExcelParser("xlsx", 0, "")
+	assert.Nil(t, err)
+
+	result, rowErrs, err := ParseAs[DateStruct](ctx, excelParser, rows, true)
+	assert.Nil(t, err)
+	assert.Equal(t, len(rowErrs), 0)
+	assert.Equal(t, result[0].Date, time.Date(2024, 1, 30, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, result[0].WhatTime, time.Date(2024, 1, 30, 12, 0, 0, 0, time.UTC).UnixNano())
+	assert.Equal(t, result[1].Date, result[0].Date)
+	assert.Equal(t, result[1].WhatTime, result[0].WhatTime)
+
+	excelParser, err = NewExcelParser("xlsx", 0, "", WithDate1904())
+	assert.Nil(t, err)
+
+	result, _, err = ParseAs[DateStruct](ctx, excelParser, rows, true)
+	assert.Nil(t, err)
+	assert.Equal(t, result[0].Date, time.Date(2028, 1, 31, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, result[1].Date, time.Date(2024, 1, 30, 0, 0, 0, 0, time.UTC))
+
+	rows = [][]string{
+		{"date", "whatTime"},
+		{"2024", ""},
+		{"1", ""},
+		{"", "9999"},
+	}
+	_, rowErrs, err = ParseAs[DateStruct](ctx, mustExcelParser(t), rows, true)
+	assert.Nil(t, err)
+	assert.Equal(t, len(rowErrs), 3)
+	for _, rowErr := range rowErrs {
+		assert.Equal(t, rowErr.ErrorCode, ERROR_PARSE)
+	}
+
+	result, rowErrs, err = ParseAs[DateStruct](ctx, mustExcelParser(t, WithRawCellValue()), rows, true)
+	assert.Nil(t, err)
+	assert.Equal(t, len(rowErrs), 0)
+	assert.Equal(t, result[0].Date, time.Date(1905, 7, 16, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, result[1].Date.Year(), 1899)
+}
+
+type LayoutStruct struct {
+	Day     time.Time `excel:"day" layout:"02/01/2006"`
+	Local   time.Time `excel:"local" tz:"Asia/Shanghai"`
+	Default time.Time `excel:"default"`
+}
+
+func TestParseLayout(t *testing.T) {
+	ctx := context.Background()
+
+	rows := [][]string{
+		{"day", "local", "default"},
+		{"03/04/2024", "2024-01-30 08:00:00", "2024-01-30 08:00:00"},
+	}
+
+	excelParser, err := NewExcelParser("xlsx", 0, "")
+	assert.Nil(t, err)
+
+	result, _, err := ParseAs[LayoutStruct](ctx, excelParser, rows, true)
+	assert.Nil(t, err)
+	assert.Equal(t, result[0].Day, time.Date(2024, 4, 3, 0, 0, 0, 0, time.UTC))
+	assert.That(t, result[0].Local.Equal(time.Date(2024, 1, 30, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, result[0].Default, time.Date(2024, 1, 30, 8, 0, 0, 0, time.UTC))
+
+	excelParser, err = NewExcelParser("xlsx", 0, "", WithLocation(time.FixedZone("UTC+1", 3600)))
+	assert.Nil(t, err)
+
+	result, _, err = ParseAs[LayoutStruct](ctx, excelParser, rows, true)
+	assert.Nil(t, err)
+	assert.That(t, result[0].Local.Equal(time.Date(2024, 1, 30, 0, 0, 0, 0, time.UTC)))
+	assert.That(t, result[0].Default.Equal(time.Date(2024, 1, 30, 7, 0, 0, 0, time.UTC)))
+}
+
+func TestNumberFormatNormalize(t *testing.T) {
+	cases := []struct {
+		locale string
+		field  string
+		want   string
+	}{
+		{"en", "1,234.56", "1234.56"},
+		{"de", "1.234,56", "1234.56"},
+		{"fr", "1 234,56", "1234.56"},
+		{"zh", "¥1,200", "1200"},
+		{"en", "-$1,200.5", "-1200.5"},
+		{"en", "12.5%", "0.125"},
+		{"en", "(300)", "-300"},
+		{"en", "(¥1,200.00)", "-1200"},
+	}
+	for _, c := range cases {
+		got, err := NumberLocales[c.locale].Normalize(c.field)
+		assert.Nil(t, err)
+		assert.Equal(t, got, c.want)
+	}
+
+	_, err := NumberLocales["en"].Normalize("12a")
+	assert.Error(t, err)
+}
+
+type NumberStruct struct {
+	Amount   float64         `excel:"amount"`
+	Price    decimal.Decimal `excel:"price" number:"de"`
+	Quantity uint            `excel:"quantity"`
+	Rate     float64         `excel:"rate"`
+}
+
+func TestParseNumberFormat(t *testing.T) {
+	ctx := context.Background()
+
+	rows := [][]string{
+		{"amount", "price", "quantity", "rate"},
+		{"(1,234.5)", "1.234,56", "1,000", "12.5%"},
+	}
+
+	excelParser, err := NewExcelParser("xlsx", 0, "")
+	assert.Nil(t, err)
+
+	_, rowErrs, err := ParseAs[NumberStruct](ctx, excelParser, rows, true)
+	assert.Nil(t, err)
+	assert.Equal(t, len(rowErrs), 3)
+
+	excelParser, err = NewExcelParser("xlsx", 0, "", WithNumberFormat(NumberLocales["en"]))
+	assert.Nil(t, err)
+
+	result, rowErrs, err := ParseAs[NumberStruct](ctx, excelParser, rows, true)
+	assert.Nil(t, err)
+	assert.Equal(t, len(rowErrs), 0)
+	assert.Equal(t, result[0].Amount, -1234.5)
+	assert.Equal(t, result[0].Price.String(), "1234.56")
+	assert.Equal(t, result[0].Quantity, uint(1000))
+	assert.Equal(t, result[0].Rate, 0.125)
+}
+
+type BoolStruct struct {
+	IsStaff bool  `excel:"isStaff"`
+	Enabled *bool `excel:"enabled" bool:"启用|停用"`
+}
+
+func TestParseBool(t *testing.T) {
+	ctx := context.Background()
+
+	rows := [][]string{
+		{"isStaff", "enabled"},
+		{"Y", "启用"},
+		{"否", "停用"},
+		{"✓", ""},
+		{"maybe", "是"},
+	}
+
+	excelParser, err := NewExcelParser("xlsx", 0, "")
+	assert.Nil(t, err)
+
+	result, rowErrs, err := ParseAs[BoolStruct](ctx, excelParser, rows, true)
+	assert.Nil(t, err)
+	assert.Equal(t, result[0].IsStaff, true)
+	assert.Equal(t, *result[0].Enabled, true)
+	assert.Equal(t, result[1].IsStaff, false)
+	assert.Equal(t, *result[1].Enabled, false)
+	assert.Equal(t, result[2].IsStaff, true)
+	assert.Nil(t, result[2].Enabled)
+	assert.Equal(t, len(rowErrs), 2)
+}
+
+var orderStatus = map[string]interface{}{
+	"待付款": 0,
+	"已付款": 1,
+	"已发货": 2,
+}
+
+type EnumStruct struct {
+	OrderNo string `excel:"orderNo,required"`
+	Status  int8   `excel:"status,required" enum:"orderStatus"`
+	Channel *int   `excel:"channel" enum:"orderStatus"`
+}
+
+func TestParseEnum(t *testing.T) {
+	ctx := context.Background()
+
+	rows := [][]string{
+		{"orderNo", "status", "channel"},
+		{"A001", "已发货", "待付款"},
+		{"A002", " 已付款 ", ""},
+		{"A003", "已签收", ""},
+	}
+
+	excelParser := mustExcelParser(t, WithEnum("orderStatus", orderStatus))
+	result, rowErrs, err := ParseAs[EnumStruct](ctx, excelParser, rows, true)
+	assert.Nil(t, err)
+	assert.Equal(t, len(result), 2)
+	assert.Equal(t, result[0].Status, int8(2))
+	assert.Equal(t, *result[0].Channel, 0)
+	assert.Equal(t, result[1].Status, int8(1))
+	assert.Nil(t, result[1].Channel)
+	assert.Equal(t, len(rowErrs), 1)
+	assert.Equal(t, rowErrs[0].Row, 4)
+	assert.Equal(t, rowErrs[0].ErrorCode, ERROR_ENUM)
+
+	_, _, err = ParseAs[EnumStruct](ctx, excelParser, rows, false)
+	assert.Error(t, err)
+
+	_, _, err = ParseAs[EnumStruct](ctx, mustExcelParser(t), rows, true)
+	assert.Error(t, err)
+}
+
+type AliasStruct struct {
+	Name string `excel:"name|full name|姓名,required"`
+	Age  int    `excel:"age|年龄"`
+}
+
+func TestParseHeaderAliases(t *testing.T) {
+	ctx := context.Background()
+
+	rows := [][]string{
+		{"姓名", " age "},
+		{"Tom", "20"},
+	}
+	result, _, err := ParseAs[AliasStruct](ctx, mustExcelParser(t), rows, true)
+	assert.Nil(t, err)
+	assert.Equal(t, result[0].Name, "Tom")
+	assert.Equal(t, result[0].Age, 20)
+
+	rows[0] = []string{"Ｆｕｌｌ　NAME", "Age"}
+	_, _, err = ParseAs[AliasStruct](ctx, mustExcelParser(t), rows, true)
+	assert.Error(t, err)
+
+	excelParser := mustExcelParser(t, WithHeaderMatch(HeaderIgnoreCase|HeaderIgnoreWidth|HeaderIgnoreSpace))
+	result, _, err = ParseAs[AliasStruct](ctx, excelParser, rows, true)
+	assert.Nil(t, err)
+	assert.Equal(t, result[0].Name, "Tom")
+	assert.Equal(t, result[0].Age, 20)
+
+	rows[0] = []string{"Full Name", "age", "fullname"}
+	_, _, err = ParseAs[AliasStruct](ctx, excelParser, rows, true)
+	assert.Error(t, err)
+	assert.That(t, strings.Contains(err.Error(), "several excel titles"))
+
+	rows[0] = []string{"name", "姓名"}
+	_, _, err = ParseAs[AliasStruct](ctx, mustExcelParser(t), rows, true)
+	assert.Error(t, err)
+}
+
+type VendorStruct struct {
+	SKU      string `excel:"sku,required"`
+	Quantity int    `excel:"quantity" excelRegex:"(?i)^(qty|quantity)"`
+	Price    int    `excel:"price"`
+}
+
+func TestParseHeaderReport(t *testing.T) {
+	ctx := context.Background()
+
+	rows := [][]string{
+		{"sku", "Qty.", "prise", "remark"},
+		{"A1", "3", "20", "gift"},
+	}
+
+	excelParser := mustExcelParser(t, WithFuzzyHeaders(1))
+	output := make([]*VendorStruct, 0)
+	result, err := excelParser.ParseWithResult(ctx, rows, &output, true)
+	assert.Nil(t, err)
+	assert.Equal(t, output[0].Quantity, 3)
+	assert.Equal(t, output[0].Price, 20)
+	assert.DeepEqual(t, result.Header, &HeaderMatchReport{
+		Fields: []FieldMatch{
+			{Field: "SKU", Column: "sku", Index: 0, Rule: MatchExact},
+			{Field: "Quantity", Column: "Qty.", Index: 1, Rule: MatchRegex},
+			{Field: "Price", Column: "prise", Index: 2, Rule: MatchFuzzy},
+		},
+		Unused: []ColumnTitle{{Index: 3, Title: "remark"}},
+	})
+
+	rows[0] = []string{"sku", "qty", "quantity ordered", "price"}
+	_, err = excelParser.ParseWithResult(ctx, rows, &output, true)
+	assert.Error(t, err)
+
+	rows[0] = []string{"sku", "qty", "prices", "price"}
+	result, err = excelParser.ParseWithResult(ctx, rows, &output, true)
+	assert.Nil(t, err)
+	assert.Equal(t, result.Header.Fields[2], FieldMatch{Field: "Price", Column: "price", Index: 3, Rule: MatchExact})
+	assert.DeepEqual(t, result.Header.Unused, []ColumnTitle{{Index: 2, Title: "prices"}})
+
+	rows[0] = []string{"sku", "qty", "pirse"}
+	result, err = excelParser.ParseWithResult(ctx, rows, &output, true)
+	assert.Nil(t, err)
+	assert.Equal(t, output[0].Price, 0)
+	assert.Equal(t, len(result.Header.Fields), 2)
+	assert.DeepEqual(t, result.Header.Unused, []ColumnTitle{{Index: 2, Title: "pirse"}})
+
+	assert.Equal(t, levenshtein("数量", "数目"), 1)
+	assert.Equal(t, levenshtein("kitten", "sitting"), 3)
+}
+
+type OptionalStruct struct {
+	Name   string `excel:"name,required"`
+	Remark string `excel:"remark" default:"none"`
+	Score  *int   `excel:"score"`
+}
+
+func TestParseMissingColumns(t *testing.T) {
+	ctx := context.Background()
+
+	rows := [][]string{
+		{"name"},
+		{"Tom"},
+		{"Jerry"},
+	}
+
+	output := make([]*OptionalStruct, 0)
+	result, err := mustExcelParser(t).ParseWithResult(ctx, rows, &output, false)
+	assert.Nil(t, err)
+	assert.Equal(t, len(output), 2)
+	assert.Equal(t, output[1].Remark, "none")
+	assert.Nil(t, output[1].Score)
+	assert.Equal(t, len(result.RowErrs), 0)
+	assert.Equal(t, len(result.Warnings), 2)
+	assert.Equal(t, result.Warnings[0].Column, "remark")
+	assert.Equal(t, result.Warnings[0].Row, 1)
+	assert.Equal(t, result.Warnings[0].ErrorCode, ERROR_FIELD_MATCH)
+
+	_, rowErrs, err := ParseAs[OptionalStruct](ctx, mustExcelParser(t), rows, false)
+	assert.Nil(t, err)
+	assert.Equal(t, len(rowErrs), 2)
+	assert.Equal(t, rowErrs[0].Column, "remark")
+	assert.Equal(t, rowErrs[0].ErrorCode, ERROR_FIELD_MATCH)
+
+	_, err = mustExcelParser(t, WithStrictColumns()).ParseWithResult(ctx, rows, &output, true)
+	assert.Error(t, err)
+}
+
+type ExtraStruct struct {
+	Name  string            `excel:"name,required"`
+	Extra map[string]string `excel:",extra"`
+}
+
+func TestParseUnknownColumns(t *testing.T) {
+	ctx := context.Background()
+
+	rows := [][]string{
+		{"name", "color", "", "size"},
+		{"Tom", "red", "x", "L"},
+		{"Jerry", "blue"},
+	}
+
+	excelParser := mustExcelParser(t, WithDisallowUnknownColumns())
+	_, _, err := ParseAs[OptionalStruct](ctx, excelParser, rows, true)
+	assert.Error(t, err)
+	assert.That(t, strings.Contains(err.Error(), "[color], [size]"))
+
+	result, _, err := ParseAs[ExtraStruct](ctx, excelParser, rows, true)
+	assert.Nil(t, err)
+	assert.DeepEqual(t, result[0].Extra, map[string]string{"color": "red", "size": "L"})
+	assert.DeepEqual(t, result[1].Extra, map[string]string{"color": "blue", "size": ""})
+}
+
+type ValidateStruct struct {
+	Name     string     `excel:"name,required" validate:"min=2,max=10"`
+	Age      *int       `excel:"age" validate:"min=0,max=150"`
+	Code     string     `excel:"code" validate:"len=4,regex=^[A-Z]{2}[0-9]{0,2}$"`
+	Color    string     `excel:"color" validate:"oneof=red green blue"`
+	Email    string     `excel:"email" validate:"email"`
+	Phone    string     `excel:"phone" validate:"phone"`
+	Birthday *time.Time `excel:"birthday" validate:"date-range=1900-01-01~2025-12-31"`
+}
+
+func TestParseValidate(t *testing.T) {
+	ctx := context.Background()
+
+	rows := [][]string{
+		{"name", "age", "code", "color", "email", "phone", "birthday"},
+		{"Tom", "20", "AB12", "red", "tom@example.com", "+86 138-0013-8000", "2000-01-02"},
+		{"Jerry", "200", "AB1", "pink", "jerry@", "12", "1800-01-01"},
+		{"J", "20"},
+	}
+
+	result, rowErrs, err := ParseAs[ValidateStruct](ctx, mustExcelParser(t), rows, true)
+	assert.Nil(t, err)
+	assert.Equal(t, len(result), 2)
+	assert.Equal(t, *result[0].Age, 20)
+	assert.Equal(t, result[0].Code, "AB12")
+	assert.Nil(t, result[1].Age)
+	assert.Equal(t, result[1].Color, "")
+	assert.Nil(t, result[1].Birthday)
+	assert.Equal(t, len(rowErrs), 7)
+	for _, rowErr := range rowErrs {
+		assert.Equal(t, rowErr.ErrorCode, ERROR_VALIDATE)
+	}
+
+	_, _, err = ParseAs[ValidateStruct](ctx, mustExcelParser(t), rows, false)
+	assert.Error(t, err)
+
+	type BadStruct struct {
+		Count int `excel:"count" validate:"email"`
+	}
+	_, _, err = ParseAs[BadStruct](ctx, mustExcelParser(t), rows, true)
+	assert.Error(t, err)
+}
+
+type PeriodStruct struct {
+	Name  string    `excel:"name,required"`
+	Start time.Time `excel:"start,required"`
+	End   time.Time `excel:"end,required"`
+	Days  int
+}
+
+func (p *PeriodStruct) BeforeRow(ctx context.Context, row []string) error {
+	if strings.HasPrefix(row[0], "#") {
+		return errors.New("comment row")
+	}
+	return nil
+}
+
+func (p *PeriodStruct) AfterParse(ctx context.Context) error {
+	p.Days = int(p.End.Sub(p.Start).Hours() / 24)
+	return nil
+}
+
+func (p *PeriodStruct) ValidateRow() []FieldError {
+	if p.End.Before(p.Start) {
+		return []FieldError{{Column: "end", Msg: "end date must be after start date"}}
+	}
+	return nil
+}
+
+func TestParseRowHooks(t *testing.T) {
+	ctx := context.Background()
+
+	rows := [][]string{
+		{"name", "start", "end"},
+		{"spring", "2024-03-01", "2024-03-11"},
+		{"# note", "2024-03-01", "2024-03-11"},
+		{"autumn", "2024-09-11", "2024-09-01"},
+	}
+
+	result, rowErrs, err := ParseAs[PeriodStruct](ctx, mustExcelParser(t), rows, true)
+	assert.Nil(t, err)
+	assert.Equal(t, len(result), 1)
+	assert.Equal(t, result[0].Days, 10)
+	assert.Equal(t, len(rowErrs), 2)
+	assert.Equal(t, rowErrs[0].Row, 3)
+	assert.Equal(t, rowErrs[0].ErrorCode, ERROR_ROW)
+	assert.Equal(t, rowErrs[1].Row, 4)
+	assert.Equal(t, rowErrs[1].Column, "end")
+	assert.Equal(t, rowErrs[1].Cell, "C4")
+	assert.Equal(t, rowErrs[1].Field, "End")
+	assert.Equal(t, rowErrs[0].Col, -1)
+
+	_, _, err = ParseAs[PeriodStruct](ctx, mustExcelParser(t), rows, false)
+	assert.Error(t, err)
+}
+
+func TestParseExhaustiveErrors(t *testing.T) {
+	ctx := context.Background()
+
+	rows := [][]string{
+		{"name", "age", "score"},
+		{"", "old", "high"},
+		{"Lucas", "18", "92.5"},
+	}
+
+	result, rowErrs, err := ParseAs[RowStruct](ctx, mustExcelParser(t), rows, true)
+	assert.Nil(t, err)
+	assert.Equal(t, len(result), 1)
+	assert.Equal(t, len(rowErrs), 1)
+	assert.Equal(t, rowErrs[0].ErrorCode, ERROR_REQUIRED)
+
+	for _, opts := range [][]Option{{WithExhaustiveErrors()}, {WithExhaustiveErrors(), WithWorkers(2)}} {
+		output := make([]*RowStruct, 0)
+		result, err := mustExcelParser(t, opts...).ParseWithResult(ctx, rows, &output, true)
+		assert.Nil(t, err)
+		assert.Equal(t, len(output), 1)
+		assert.Equal(t, output[0].Name, "Lucas")
+		assert.Equal(t, len(result.RowErrs), 3)
+	}
+
+	exhaustive := mustExcelParser(t, WithExhaustiveErrors())
+	_, rowErrs, err = ParseAs[RowStruct](ctx, exhaustive, rows, true)
+	assert.Nil(t, err)
+	assert.Equal(t, rowErrs[0].Column, "name")
+	assert.Equal(t, rowErrs[1].Column, "age")
+	assert.Equal(t, rowErrs[2].Column, "score")
+	assert.Equal(t, rowErrs[2].ErrorCode, ERROR_PARSE)
+}
+
+func TestCellError(t *testing.T) {
+	ctx := context.Background()
+
+	rows := [][]string{
+		{"name", "age", "score"},
+		{"Lucas", "old", "92.5"},
+	}
+
+	_, _, err := ParseAs[RowStruct](ctx, mustExcelParser(t), rows, false)
+	var cellErr CellError
+	assert.That(t, errors.As(err, &cellErr))
+	assert.That(t, errors.Is(err, ErrParse))
+	assert.That(t, !errors.Is(err, ErrRequired))
+	assert.Equal(t, cellErr.Cell, "B2")
+	assert.Equal(t, cellErr.Field, "Age")
+	assert.Equal(t, cellErr.Value, "old")
+	var numErr *strconv.NumError
+	assert.That(t, errors.As(err, &numErr))
+
+	workerRows := [][]string{rows[0]}
+	for i := 0; i < 50; i++ {
+		workerRows = append(workerRows, []string{"Tom", "age" + strconv.Itoa(i), "80"})
+	}
+	output := make([]*RowStruct, 0)
+	err = mustExcelParser(t, WithWorkers(4)).Parse(ctx, workerRows, &output, false)
+	assert.That(t, errors.As(err, &cellErr))
+	assert.That(t, errors.Is(err, ErrParse))
+	assert.Equal(t, cellErr.Cell, "B2")
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	for i := 1; i < len(workerRows); i++ {
+		workerRows[i][1] = "18"
+	}
+	err = mustExcelParser(t, WithWorkers(2)).Parse(canceled, workerRows, &output, false)
+	assert.That(t, errors.Is(err, context.Canceled))
+	assert.Equal(t, len(output), 0)
+
+	rows[1] = []string{"", "18"}
+	_, _, err = ParseAs[RowStruct](ctx, mustExcelParser(t), rows, false)
+	assert.That(t, errors.Is(err, ErrRequired))
+
+	rows[0] = []string{"name", "score"}
+	_, _, err = ParseAs[RowStruct](ctx, mustExcelParser(t), rows, true)
+	assert.That(t, errors.Is(err, ErrFieldMatch))
+
+	type EIndexStruct struct {
+		Name string `excel:"name" eIndex:"9"`
+	}
+	_, _, err = ParseAs[EIndexStruct](ctx, mustExcelParser(t), rows, true)
+	assert.That(t, errors.Is(err, ErrEIndexExceed))
+
+	type UnknownStruct struct {
+		Name string `excel:"name" parser:"unknown"`
+	}
+	rows[1] = []string{"Lucas", "92.5"}
+	_, _, err = ParseAs[UnknownStruct](ctx, mustExcelParser(t), rows, true)
+	assert.That(t, errors.Is(err, ErrNotRegistered))
+}
+
+type RangeStruct struct {
+	Level int8 `excel:"level" enum:"big"`
+	Count int8 `excel:"count" parser:"int"`
+	Size  uint `excel:"size" parser:"float64"`
+}
+
+func TestParseNumberRange(t *testing.T) {
+	ctx := context.Background()
+
+	rows := [][]string{
+		{"level", "count", "size"},
+		{"x", "300", "-1"},
+		{"y", "12", "2.5"},
+		{"z", "-12", "3"},
+	}
+
+	excelParser := mustExcelParser(t, WithEnum("big", map[string]interface{}{"x": 300, "y": 1.9, "z": 2.0}))
+	result, rowErrs, err := ParseAs[RangeStruct](ctx, excelParser, rows, true)
+	assert.Nil(t, err)
+	assert.Equal(t, len(result), 3)
+	assert.Equal(t, result[0].Level, int8(0))
+	assert.Equal(t, result[0].Count, int8(0))
+	assert.Equal(t, result[1].Count, int8(12))
+	assert.Equal(t, result[2].Level, int8(2))
+	assert.Equal(t, result[2].Size, uint(3))
+	assert.Equal(t, len(rowErrs), 5)
+	for _, rowErr := range rowErrs {
+		assert.Equal(t, rowErr.ErrorCode, ERROR_PARSE)
+	}
+}
+
+func TestParseRequiredOption(t *testing.T) {
+	ctx := context.Background()
+
+	type SubmitStruct struct {
+		Name       string `excel:"name,required"`
+		RequiredBy string `excel:"required_by|提交人"`
+	}
+
+	rows := [][]string{
+		{"name"},
+		{"Tom"},
+		{""},
+	}
+	result, rowErrs, err := ParseAs[SubmitStruct](ctx, mustExcelParser(t), rows, true)
+	assert.Nil(t, err)
+	assert.Equal(t, len(result), 1)
+	assert.Equal(t, len(rowErrs), 2)
+	assert.Equal(t, rowErrs[0].ErrorCode, ERROR_FIELD_MATCH)
+	assert.Equal(t, rowErrs[1].ErrorCode, ERROR_REQUIRED)
+}
+
+func TestParseCustomTimeParser(t *testing.T) {
+	ctx := context.Background()
+
+	rows := [][]string{
+		{"date", "whatTime"},
+		{"yesterday", ""},
+	}
+
+	yesterday := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	excelParser := mustExcelParser(t,
+		WithFieldParser("Time", func(field string) (interface{}, error) {
+			return yesterday, nil
+		}),
+		WithLocation(time.UTC),
+		WithDate1904(),
+	)
+	result, rowErrs, err := ParseAs[DateStruct](ctx, excelParser, rows, true)
+	assert.Nil(t, err)
+	assert.Equal(t, len(rowErrs), 0)
+	assert.Equal(t, result[0].Date, yesterday)
+}
+
+func TestParseCustomBoolParser(t *testing.T) {
+	ctx := context.Background()
+
+	rows := [][]string{
+		{"isStaff", "enabled"},
+		{"staff", "on"},
+	}
+
+	excelParser := mustExcelParser(t,
+		WithFieldParser("bool", func(field string) (interface{}, error) {
+			return field == "staff" || field == "on", nil
+		}),
+		WithBoolValues(BoolValues{True: []string{"是"}, False: []string{"否"}}),
+	)
+	result, rowErrs, err := ParseAs[BoolStruct](ctx, excelParser, rows, true)
+	assert.Nil(t, err)
+	assert.Equal(t, len(rowErrs), 0)
+	assert.Equal(t, result[0].IsStaff, true)
+	assert.Equal(t, *result[0].Enabled, true)
+}
@@ -0,0 +1,208 @@
+package excel2struct
+
+import (
+	"fmt"
+	"net/mail"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	decimalType  = reflect.TypeOf(decimal.Decimal{})
+	durationType = reflect.TypeOf(time.Duration(0))
+	timeType     = reflect.TypeOf(time.Time{})
+	// phoneRegexp matches phone numbers with an optional country code, e.g. "+86 138-0013-8000"
+	phoneRegexp = regexp.MustCompile(`^\+?[0-9][0-9 ()\-]*[0-9]$`)
+)
+
+// validateRule is a rule of the `validate` tag, checked against the parsed value of a cell.
+type validateRule struct {
+	rule  string // the rule as written in the tag, e.g. "min=0"
+	check func(value interface{}) bool
+}
+
+// validate checks value against the rules of the field and returns the first rule it fails.
+func (fieldMeta FieldMetadata) validate(value interface{}) (string, bool) {
+	value = indirect(value)
+	for _, vr := range fieldMeta.validators {
+		if !vr.check(value) {
+			return vr.rule, false
+		}
+	}
+	return "", true
+}
+
+// parseValidateTag compiles the rules of a `validate` tag, e.g. `validate:"min=0,max=150"`,
+// for fields of fieldType. Rules are separated by ",", and regex takes the rest of
+// the tag so that its expression may contain commas. Times in a date-range without
+// a time zone are read in loc.
+func parseValidateTag(tag string, fieldType reflect.Type, loc *time.Location) ([]validateRule, error) {
+	var rules []validateRule
+	parts := strings.Split(tag, ",")
+	for i := 0; i < len(parts); i++ {
+		rule := strings.TrimSpace(parts[i])
+		if rule == "" {
+			continue
+		}
+		name, arg, _ := strings.Cut(rule, "=")
+		if name == "regex" {
+			// the expression may contain commas
+			arg = strings.Join(append([]string{arg}, parts[i+1:]...), ",")
+			rule = name + "=" + arg
+			i = len(parts)
+		}
+		check, err := newValidateCheck(name, arg, fieldType, loc)
+		if err != nil {
+			return nil, fmt.Errorf("invalid validate rule [%s]: %v", rule, err)
+		}
+		rules = append(rules, validateRule{rule: rule, check: check})
+	}
+	return rules, nil
+}
+
+func newValidateCheck(name, arg string, fieldType reflect.Type, loc *time.Location) (func(value interface{}) bool, error) {
+	isString := fieldType.Kind() == reflect.String
+	isNumber := isNumberKind(fieldType.Kind()) || fieldType == decimalType
+	switch name {
+	case "min", "max":
+		bound, err := strconv.ParseFloat(strings.TrimSpace(arg), 64)
+		if err != nil {
+			return nil, err
+		}
+		if !isString && !isNumber {
+			return nil, fmt.Errorf("not supported by %s", fieldType)
+		}
+		return func(value interface{}) bool {
+			n, ok := validateNumber(value)
+			if !ok {
+				return false
+			}
+			if name == "min" {
+				return n >= bound
+			}
+			return n <= bound
+		}, nil
+	case "len":
+		length, err := strconv.Atoi(strings.TrimSpace(arg))
+		if err != nil {
+			return nil, err
+		}
+		if !isString {
+			return nil, fmt.Errorf("not supported by %s", fieldType)
+		}
+		return func(value interface{}) bool {
+			return utf8.RuneCountInString(validateString(value)) == length
+		}, nil
+	case "regex":
+		regex, err := regexp.Compile(arg)
+		if err != nil {
+			return nil, err
+		}
+		if !isString {
+			return nil, fmt.Errorf("not supported by %s", fieldType)
+		}
+		return func(value interface{}) bool {
+			return regex.MatchString(validateString(value))
+		}, nil
+	case "oneof":
+		values := strings.Fields(arg)
+		if len(values) == 0 {
+			return nil, fmt.Errorf("no values")
+		}
+		return func(value interface{}) bool {
+			s := fmt.Sprint(value)
+			for _, v := range values {
+				if s == v {
+					return true
+				}
+			}
+			return false
+		}, nil
+	case "email":
+		if !isString {
+			return nil, fmt.Errorf("not supported by %s", fieldType)
+		}
+		return func(value interface{}) bool {
+			s := validateString(value)
+			address, err := mail.ParseAddress(s)
+			return err == nil && address.Address == s
+		}, nil
+	case "phone":
+		if !isString {
+			return nil, fmt.Errorf("not supported by %s", fieldType)
+		}
+		return func(value interface{}) bool {
+			s := validateString(value)
+			if !phoneRegexp.MatchString(s) {
+				return false
+			}
+			var digits int
+			for _, r := range s {
+				if r >= '0' && r <= '9' {
+					digits++
+				}
+			}
+			return digits >= 7 && digits <= 15
+		}, nil
+	case "date-range":
+		from, to, ok := strings.Cut(arg, "~")
+		if !ok {
+			return nil, fmt.Errorf("missing ~")
+		}
+		if fieldType != timeType {
+			return nil, fmt.Errorf("not supported by %s", fieldType)
+		}
+		var bounds [2]*time.Time
+		for j, bound := range []string{from, to} {
+			if bound = strings.TrimSpace(bound); bound == "" {
+				continue
+			}
+			t, err := parseTime(bound, timeLayouts, loc, false, false)
+			if err != nil {
+				return nil, err
+			}
+			bounds[j] = &t
+		}
+		return func(value interface{}) bool {
+			t, ok := value.(time.Time)
+			if !ok {
+				return false
+			}
+			return (bounds[0] == nil || !t.Before(*bounds[0])) && (bounds[1] == nil || !t.After(*bounds[1]))
+		}, nil
+	}
+	return nil, fmt.Errorf("unknown rule")
+}
+
+// validateNumber returns the number a min or max rule compares, the length of strings.
+func validateNumber(value interface{}) (float64, bool) {
+	if d, ok := value.(decimal.Decimal); ok {
+		return d.InexactFloat64(), true
+	}
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.String:
+		return float64(utf8.RuneCountInString(rv.String())), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	}
+	return 0, false
+}
+
+func validateString(value interface{}) string {
+	rv := reflect.ValueOf(value)
+	if rv.Kind() == reflect.String {
+		return rv.String()
+	}
+	return fmt.Sprint(value)
+}
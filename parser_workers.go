@@ -8,10 +8,24 @@ import (
 	"github.com/lisongxi/goutils"
 )
 
-func (ep *ExcelParser) parseWithWorkers(ctx context.Context, structFieldMetaMap map[string]FieldMetadata, titleMap map[string]int, structType, sliceType reflect.Type, outputValue reflect.Value, rows [][]string, skip bool) {
+// parseWithWorkers parses the rows with ep.workers goroutines, keeping their order.
+// As in the sequential path, the error of the first row that can not be skipped is
+// returned and the rows after it are left unparsed. If ctx is done before all rows
+// are parsed its error is returned. The output is only set when no error is returned.
+func (ep *ExcelParser) parseWithWorkers(ctx context.Context, structFieldMetaMap map[string]FieldMetadata, titleMap map[string]int, structType, sliceType reflect.Type, outputValue reflect.Value, rows [][]string, skip bool) error {
 	defer close(ep.errChan)
 	var wg sync.WaitGroup
 
+	// errIndex is the index of the first row failing so far, the rows after it are skipped
+	var mu sync.Mutex
+	var firstErr error
+	errIndex := len(rows)
+	stopped := func(index int) bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return ctx.Err() != nil || index > errIndex
+	}
+
 	rowIndexChan := make(chan int, ep.workers)
 	resultChan := make(chan struct {
 		index int
@@ -24,9 +38,20 @@ func (ep *ExcelParser) parseWithWorkers(ctx context.Context, structFieldMetaMap
 		goutils.SafeGo(ctx, func() {
 			defer wg.Done()
 			for index := range rowIndexChan {
+				if stopped(index) {
+					continue
+				}
 				out := reflect.New(structType)
-				parsedErr := ep.parseRowToStruct(ctx, index+ep.headerIndex+2, structFieldMetaMap, rows[index], titleMap, out, skip)
+				valid, parsedErr := ep.parseRowToStruct(ctx, index+ep.headerIndex+2, structFieldMetaMap, rows[index], titleMap, out, skip)
 				if parsedErr != nil {
+					mu.Lock()
+					if index < errIndex {
+						errIndex, firstErr = index, parsedErr
+					}
+					mu.Unlock()
+					continue
+				}
+				if !valid {
 					continue
 				}
 				resultChan <- struct {
@@ -39,10 +64,17 @@ func (ep *ExcelParser) parseWithWorkers(ctx context.Context, structFieldMetaMap
 
 	// 分发任务 distribute tasks
 	go func() {
+		defer close(rowIndexChan)
 		for i := range rows {
-			rowIndexChan <- i
+			if stopped(i) {
+				return
+			}
+			select {
+			case rowIndexChan <- i:
+			case <-ctx.Done():
+				return
+			}
 		}
-		close(rowIndexChan)
 	}()
 
 	// 等待任务完成 wait for task to complete
@@ -56,6 +88,12 @@ func (ep *ExcelParser) parseWithWorkers(ctx context.Context, structFieldMetaMap
 	for res := range resultChan {
 		resultMap[res.index] = res.value
 	}
+	if firstErr != nil {
+		return firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 
 	// End Result
 	results := reflect.MakeSlice(sliceType, 0, len(rows))
@@ -65,4 +103,5 @@ func (ep *ExcelParser) parseWithWorkers(ctx context.Context, structFieldMetaMap
 		}
 	}
 	outputValue.Elem().Set(results)
+	return nil
 }
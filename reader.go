@@ -9,11 +9,31 @@ import (
 	"io"
 )
 
+// Reader reads the configured sheet from reader into output and stores the row
+// errors of this call in ep.RowErrs. Use ReaderWithResult when ep is shared
+// between goroutines.
 func (ep *ExcelParser) Reader(ctx context.Context, reader io.ReadSeeker, output interface{}, skip bool, args ...interface{}) (err error) {
-	var rowData [][]string
+	result, err := ep.ReaderWithResult(ctx, reader, output, skip, args...)
+	ep.keepRowErrs(result)
+	return
+}
+
+// ReaderWithResult reads the configured sheet from reader into output and returns
+// the row errors of this call. It is safe to call concurrently on the same ExcelParser.
+func (ep *ExcelParser) ReaderWithResult(ctx context.Context, reader io.ReadSeeker, output interface{}, skip bool, args ...interface{}) (*Result, error) {
+	call := ep.session()
+	rowData, err := call.readRows(reader, args...)
+	if err != nil || len(rowData) == 0 {
+		return call.result(), err
+	}
+	err = call.parse(ctx, rowData, output, skip)
+	return call.result(), err
+}
+
+func (ep *ExcelParser) readRows(reader io.ReadSeeker, args ...interface{}) (rowData [][]string, err error) {
 	switch ep.fileType {
 	case "xlsx":
-		rowData, err = ep.ReadXlsxFromReader(reader, ep.sheetName)
+		rowData, err = ep.readXlsx(reader, ep.sheetName)
 		if err != nil {
 			return
 		}
@@ -27,23 +47,38 @@ func (ep *ExcelParser) Reader(ctx context.Context, reader io.ReadSeeker, output
 			return
 		}
 	case "csv":
+		ep.sheetName = "" // csv files have no sheets
 		rowData, err = ep.ReadCsvFromReader(reader, ep.sheetName)
 		if err != nil {
 			return
 		}
 	default:
-		rowData, err = ep.ReadXlsxFromReader(reader, ep.sheetName)
+		rowData, err = ep.readXlsx(reader, ep.sheetName)
 		if err != nil {
-			return errors.New("unknown file type")
+			return nil, errors.New("unknown file type")
 		}
 	}
-	if len(rowData) == 0 {
-		return
-	}
-	err = ep.Parse(ctx, rowData, output, skip)
 	return
 }
 
+// readXlsx reads a xlsx sheet like ReadXlsxFromReader, and switches ep to the
+// 1904 date system if the workbook uses it. ep must be a session, its sheetName
+// is set to the name of the sheet read.
+func (ep *ExcelParser) readXlsx(reader io.ReadSeeker, sheetName string) ([][]string, error) {
+	file, err := excelize.OpenReader(reader)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	if sheetName == "" {
+		sheetName = file.GetSheetName(0)
+	}
+	ep.sheetName = sheetName // reported in the row errors
+	ep.date1904 = ep.date1904 || workbookDate1904(file)
+	return ep.xlsxSheetRows(file, sheetName)
+}
+
 func (ep *ExcelParser) ReadXlsxFromReader(reader io.ReadSeeker, sheetName string) ([][]string, error) {
 	file, err := excelize.OpenReader(reader)
 	if err != nil {
@@ -51,11 +86,15 @@ func (ep *ExcelParser) ReadXlsxFromReader(reader io.ReadSeeker, sheetName string
 	}
 	defer file.Close()
 
+	return ep.xlsxSheetRows(file, sheetName)
+}
+
+func (ep *ExcelParser) xlsxSheetRows(file *excelize.File, sheetName string) ([][]string, error) {
 	if sheetName == "" {
 		sheetName = file.GetSheetName(0)
 	}
 
-	rows, err := file.GetRows(sheetName)
+	rows, err := file.GetRows(sheetName, excelize.Options{RawCellValue: ep.rawCellValue})
 	if err != nil {
 		return nil, err
 	}
@@ -63,6 +102,12 @@ func (ep *ExcelParser) ReadXlsxFromReader(reader io.ReadSeeker, sheetName string
 	return rows, nil
 }
 
+// workbookDate1904 reports whether the workbook uses the 1904 date system.
+func workbookDate1904(file *excelize.File) bool {
+	props, err := file.GetWorkbookProps()
+	return err == nil && props.Date1904 != nil && *props.Date1904
+}
+
 func (ep *ExcelParser) ReadXlsFromReader(reader io.ReadSeeker, sheetName string, e string) ([][]string, error) {
 	file, err := xls.OpenReader(reader, e)
 	if err != nil {
@@ -72,16 +117,26 @@ func (ep *ExcelParser) ReadXlsFromReader(reader io.ReadSeeker, sheetName string,
 	sheet := &xls.WorkSheet{}
 	if sheetName == "" {
 		sheet = file.GetSheet(0)
-	} else {
-		for sheetIndex := 0; sheetIndex < file.NumSheets(); sheetIndex++ {
-			if w := file.GetSheet(sheetIndex); w.Name == sheetName {
-				sheet = w
-			}
-		}
+	} else if w := xlsSheet(file, sheetName); w != nil {
+		sheet = w
 	}
 	if sheet == nil {
 		return nil, nil
 	}
+	return xlsSheetRows(sheet), nil
+}
+
+func xlsSheet(file *xls.WorkBook, sheetName string) *xls.WorkSheet {
+	var sheet *xls.WorkSheet
+	for sheetIndex := 0; sheetIndex < file.NumSheets(); sheetIndex++ {
+		if w := file.GetSheet(sheetIndex); w.Name == sheetName {
+			sheet = w
+		}
+	}
+	return sheet
+}
+
+func xlsSheetRows(sheet *xls.WorkSheet) [][]string {
 	fileData := make([][]string, 0)
 	for i := 0; i < int(sheet.MaxRow); i++ {
 		row := sheet.Row(i)
@@ -92,8 +147,7 @@ func (ep *ExcelParser) ReadXlsFromReader(reader io.ReadSeeker, sheetName string,
 		}
 		fileData = append(fileData, rowData)
 	}
-
-	return fileData, nil
+	return fileData
 }
 
 func (ep *ExcelParser) ReadCsvFromReader(reader io.Reader, sheetName string) ([][]string, error) {
@@ -106,3 +160,103 @@ func (ep *ExcelParser) ReadCsvFromReader(reader io.Reader, sheetName string) ([]
 	}
 	return rows, nil
 }
+
+// rowIterator yields the rows of a sheet one at a time.
+type rowIterator interface {
+	Next() bool
+	Columns() ([]string, error)
+	Error() error
+	Close() error
+}
+
+// openRows opens the sheet as a rowIterator. xlsx and csv files are read lazily,
+// xls files are loaded at once because the xls reader does not support streaming.
+// Like readXlsx, it switches ep to the 1904 date system if the workbook uses it
+// and sets the sheetName of ep.
+func (ep *ExcelParser) openRows(reader io.ReadSeeker, sheetName string, args ...interface{}) (rowIterator, error) {
+	switch ep.fileType {
+	case "xls":
+		e := "utf-8"
+		if len(args) > 0 {
+			e = args[0].(string)
+		}
+		rowData, err := ep.ReadXlsFromReader(reader, sheetName, e)
+		if err != nil {
+			return nil, err
+		}
+		return &sliceRows{rows: rowData, cur: -1}, nil
+	case "csv":
+		ep.sheetName = "" // csv files have no sheets
+		csvReader := csv.NewReader(reader)
+		csvReader.LazyQuotes = true
+		csvReader.FieldsPerRecord = -1
+		return &csvRows{reader: csvReader}, nil
+	default:
+		file, err := excelize.OpenReader(reader)
+		if err != nil {
+			return nil, err
+		}
+		if sheetName == "" {
+			sheetName = file.GetSheetName(0)
+		}
+		rows, err := file.Rows(sheetName)
+		if err != nil {
+			file.Close()
+			return nil, err
+		}
+		ep.sheetName = sheetName
+		ep.date1904 = ep.date1904 || workbookDate1904(file)
+		return &xlsxRows{file: file, rows: rows, opts: excelize.Options{RawCellValue: ep.rawCellValue}}, nil
+	}
+}
+
+type xlsxRows struct {
+	file *excelize.File
+	rows *excelize.Rows
+	opts excelize.Options
+}
+
+func (r *xlsxRows) Next() bool                 { return r.rows.Next() }
+func (r *xlsxRows) Columns() ([]string, error) { return r.rows.Columns(r.opts) }
+func (r *xlsxRows) Error() error               { return r.rows.Error() }
+
+func (r *xlsxRows) Close() error {
+	if err := r.rows.Close(); err != nil {
+		r.file.Close()
+		return err
+	}
+	return r.file.Close()
+}
+
+type csvRows struct {
+	reader *csv.Reader
+	row    []string
+	err    error
+}
+
+func (r *csvRows) Next() bool {
+	r.row, r.err = r.reader.Read()
+	if errors.Is(r.err, io.EOF) {
+		r.err = nil
+		return false
+	}
+	return r.err == nil
+}
+
+func (r *csvRows) Columns() ([]string, error) { return r.row, nil }
+func (r *csvRows) Error() error               { return r.err }
+func (r *csvRows) Close() error               { return nil }
+
+type sliceRows struct {
+	rows [][]string
+	cur  int
+}
+
+func (r *sliceRows) Next() bool {
+	r.cur++
+	return r.cur < len(r.rows)
+}
+
+func (r *sliceRows) Columns() ([]string, error) { return r.rows[r.cur], nil }
+func (r *sliceRows) Error() error               { return nil }
+func (r *sliceRows) Close() error               { return nil }
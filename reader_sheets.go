@@ -0,0 +1,135 @@
+package excel2struct
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+
+	"github.com/extrame/xls"
+	"github.com/lisongxi/goutils"
+	"github.com/xuri/excelize/v2"
+)
+
+// ReadSheets opens the workbook once and parses every sheet named in outputs into
+// its own output, e.g. map[string]interface{}{"Orders": &orders, "Lines": &lines}.
+// Each output must be a pointer to a slice of struct pointers, as in Reader.
+// The returned results and the fatal errors are reported per sheet; a sheet
+// failing does not stop the others. With WithParallelSheets the sheets are parsed
+// concurrently. csv files have a single sheet, whatever its name.
+func (ep *ExcelParser) ReadSheets(ctx context.Context, reader io.ReadSeeker, outputs map[string]interface{}, skip bool, args ...interface{}) (map[string]*Result, error) {
+	sheetNames := make([]string, 0, len(outputs))
+	for sheetName := range outputs {
+		sheetNames = append(sheetNames, sheetName)
+	}
+	// the sheets are read and their errors joined in the order of their names
+	sort.Strings(sheetNames)
+	sheetRows, sheetErrs, date1904, err := ep.readSheets(reader, sheetNames, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		results = make(map[string]*Result, len(outputs))
+		errs    = make([]error, len(sheetNames))
+	)
+	parseSheet := func(i int, sheetName string) {
+		result := &Result{}
+		err := sheetErrs[sheetName]
+		if err == nil && len(sheetRows[sheetName]) > 0 {
+			call := ep.session()
+			call.sheetName = sheetName
+			if ep.fileType == "csv" {
+				call.sheetName = "" // csv files have no sheets
+			}
+			call.date1904 = call.date1904 || date1904
+			err = call.parse(ctx, sheetRows[sheetName], outputs[sheetName], skip)
+			result = call.result()
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		results[sheetName] = result
+		if err != nil {
+			errs[i] = fmt.Errorf("sheet [%s]: %w", sheetName, err)
+		}
+	}
+
+	for i, sheetName := range sheetNames {
+		if !ep.parallelSheets {
+			parseSheet(i, sheetName)
+			continue
+		}
+		wg.Add(1)
+		goutils.SafeGo(ctx, func() {
+			defer wg.Done()
+			parseSheet(i, sheetName)
+		})
+	}
+	wg.Wait()
+
+	return results, errors.Join(errs...)
+}
+
+// readSheets loads the rows of the given sheets, opening the workbook only once.
+// A missing sheet is reported in the returned error map. date1904 reports whether
+// a xlsx workbook uses the 1904 date system.
+func (ep *ExcelParser) readSheets(reader io.ReadSeeker, sheetNames []string, args ...interface{}) (sheetRows map[string][][]string, sheetErrs map[string]error, date1904 bool, err error) {
+	sheetRows = make(map[string][][]string, len(sheetNames))
+	sheetErrs = make(map[string]error)
+
+	switch ep.fileType {
+	case "xls":
+		e := "utf-8"
+		if len(args) > 0 {
+			e = args[0].(string)
+		}
+		file, err := xls.OpenReader(reader, e)
+		if err != nil {
+			return nil, nil, false, err
+		}
+		for _, sheetName := range sheetNames {
+			sheet := xlsSheet(file, sheetName)
+			if sheet == nil {
+				sheetErrs[sheetName] = fmt.Errorf("sheet %s does not exist", sheetName)
+				continue
+			}
+			sheetRows[sheetName] = xlsSheetRows(sheet)
+		}
+	case "csv":
+		if len(sheetNames) > 1 {
+			return nil, nil, false, errors.New("csv file has only one sheet")
+		}
+		csvReader := csv.NewReader(reader)
+		csvReader.LazyQuotes = true
+		csvReader.FieldsPerRecord = -1
+		rows, err := csvReader.ReadAll()
+		if err != nil {
+			return nil, nil, false, err
+		}
+		for _, sheetName := range sheetNames {
+			sheetRows[sheetName] = rows
+		}
+	default:
+		file, err := excelize.OpenReader(reader)
+		if err != nil {
+			return nil, nil, false, err
+		}
+		defer file.Close()
+		date1904 = workbookDate1904(file)
+		for _, sheetName := range sheetNames {
+			rows, err := file.GetRows(sheetName, excelize.Options{RawCellValue: ep.rawCellValue})
+			if err != nil {
+				sheetErrs[sheetName] = err
+				continue
+			}
+			sheetRows[sheetName] = rows
+		}
+	}
+	return sheetRows, sheetErrs, date1904, nil
+}
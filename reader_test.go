@@ -1,14 +1,17 @@
 package excel2struct
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"math"
 	"os"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/xuri/excelize/v2"
 	"github.com/zeebo/assert"
 )
 
@@ -114,3 +117,120 @@ func TestReaderWorkers(t *testing.T) {
 	err = excelParser.Reader(ctx, file, &fileStruct, false)
 	assert.Nil(t, err)
 }
+
+// newXlsxReader builds a xlsx file in memory with one sheet per map entry.
+func newXlsxReader(t *testing.T, sheets map[string][][]interface{}) *bytes.Reader {
+	f := excelize.NewFile()
+	defer f.Close()
+	for sheetName, rows := range sheets {
+		_, err := f.NewSheet(sheetName)
+		assert.Nil(t, err)
+		for idx, row := range rows {
+			cell, err := excelize.CoordinatesToCellName(1, idx+1)
+			assert.Nil(t, err)
+			assert.Nil(t, f.SetSheetRow(sheetName, cell, &row))
+		}
+	}
+	buf, err := f.WriteToBuffer()
+	assert.Nil(t, err)
+	return bytes.NewReader(buf.Bytes())
+}
+
+type OrderStruct struct {
+	OrderNo string  `excel:"orderNo,required"`
+	Amount  float64 `excel:"amount"`
+}
+
+func TestReadSheets(t *testing.T) {
+	ctx := context.Background()
+
+	file := newXlsxReader(t, map[string][][]interface{}{
+		"Orders": {
+			{"orderNo", "amount"},
+			{"A001", 10.5},
+			{"", 3},
+		},
+		"Customers": {
+			{"name", "age", "score"},
+			{"Lucas", 18, 92.5},
+		},
+	})
+
+	excelParser, err := NewExcelParser("xlsx", 0, "", WithParallelSheets())
+	assert.Nil(t, err)
+
+	var orders []*OrderStruct
+	var customers []*RowStruct
+	var missing, absent []*OrderStruct
+	results, err := excelParser.ReadSheets(ctx, file, map[string]interface{}{
+		"Orders":    &orders,
+		"Customers": &customers,
+		"Missing":   &missing,
+		"Absent":    &absent,
+	}, true)
+	assert.Error(t, err)
+	assert.That(t, strings.Contains(err.Error(), "sheet [Missing]"))
+	assert.That(t, strings.Index(err.Error(), "sheet [Absent]") >= 0)
+	assert.That(t, strings.Index(err.Error(), "sheet [Absent]") < strings.Index(err.Error(), "sheet [Missing]"))
+	assert.That(t, !strings.Contains(err.Error(), "sheet [Customers]"))
+	assert.Equal(t, len(orders), 1)
+	assert.Equal(t, len(results["Orders"].RowErrs), 1)
+	assert.Equal(t, results["Orders"].RowErrs[0].Sheet, "Orders")
+	assert.Equal(t, results["Orders"].RowErrs[0].Cell, "A3")
+	assert.Equal(t, results["Orders"].RowErrs[0].Field, "OrderNo")
+	assert.Equal(t, len(customers), 1)
+	assert.Equal(t, len(results["Customers"].RowErrs), 0)
+	assert.Equal(t, len(results["Missing"].RowErrs), 0)
+}
+
+func TestReadCsvSheet(t *testing.T) {
+	ctx := context.Background()
+
+	data := "name,age,score\nLucas,old,92.5\n"
+	excelParser, err := NewExcelParser("csv", 0, "Sheet1")
+	assert.Nil(t, err)
+
+	_, rowErrs, err := ReadAs[RowStruct](ctx, excelParser, strings.NewReader(data), true)
+	assert.Nil(t, err)
+	assert.Equal(t, len(rowErrs), 1)
+	assert.Equal(t, rowErrs[0].Sheet, "")
+
+	rowErrs, err = StreamAs(ctx, excelParser, strings.NewReader(data), true, func(rs *RowStruct) error { return nil })
+	assert.Nil(t, err)
+	assert.Equal(t, len(rowErrs), 1)
+	assert.Equal(t, rowErrs[0].Sheet, "")
+
+	var customers []*RowStruct
+	results, err := excelParser.ReadSheets(ctx, strings.NewReader(data), map[string]interface{}{"Customers": &customers}, true)
+	assert.Nil(t, err)
+	assert.Equal(t, len(results["Customers"].RowErrs), 1)
+	assert.Equal(t, results["Customers"].RowErrs[0].Sheet, "")
+}
+
+func TestReaderRawCellValue(t *testing.T) {
+	ctx := context.Background()
+
+	date := time.Date(2024, 1, 30, 12, 0, 0, 0, time.UTC)
+	data := map[string][][]interface{}{
+		"Sheet1": {
+			{"date", "whatTime"},
+			{date, date},
+		},
+	}
+
+	excelParser, err := NewExcelParser("xlsx", 0, "Sheet1", WithRawCellValue())
+	assert.Nil(t, err)
+
+	result, rowErrs, err := ReadAs[DateStruct](ctx, excelParser, newXlsxReader(t, data), true)
+	assert.Nil(t, err)
+	assert.Equal(t, len(rowErrs), 0)
+	assert.Equal(t, result[0].Date, date)
+
+	var streamed []*DateStruct
+	_, err = StreamAs(ctx, excelParser, newXlsxReader(t, data), true, func(ds *DateStruct) error {
+		streamed = append(streamed, ds)
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, streamed[0].WhatTime, date.UnixNano())
+}
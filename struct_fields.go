@@ -0,0 +1,240 @@
+package excel2struct
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+)
+
+// excelField is a struct field mapped to an excel column. Fields of embedded and
+// nested structs are flattened, so field.Index holds the full index path from the
+// root struct and name holds the column name with the prefixes of the parents.
+type excelField struct {
+	field   reflect.StructField
+	name    string
+	aliases []string // column names separated by "|" in the tag, the first is name
+	tags    []string // excel tag options after the column name, e.g. "required"
+	extra   bool     // catch-all field tagged `excel:",extra"`, it has no column name
+}
+
+// excelFields walks structType and returns its fields carrying an `excel` tag.
+// Anonymous embedded structs without a column name are flattened, and struct
+// fields with a prefix option, e.g. `excel:"shipping,prefix=Ship "`, are mapped
+// field by field with the prefix added to the column names of their fields.
+// A field tagged `excel:",extra"` is returned without a column name.
+func excelFields(structType reflect.Type) []excelField {
+	return appendExcelFields(nil, structType, "", nil, map[reflect.Type]bool{})
+}
+
+func appendExcelFields(fields []excelField, structType reflect.Type, prefix string, index []int, visited map[reflect.Type]bool) []excelField {
+	if visited[structType] {
+		return fields
+	}
+	visited[structType] = true
+	defer delete(visited, structType)
+
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		field.Index = append(append(make([]int, 0, len(index)+1), index...), i)
+
+		excelTags := strings.Split(field.Tag.Get("excel"), ",")
+		name := strings.TrimSpace(excelTags[0])
+		if name == "-" {
+			continue
+		}
+
+		if nested := nestedStruct(field.Type); nested != nil {
+			if subPrefix, ok := tagOption(excelTags[1:], "prefix"); ok {
+				fields = appendExcelFields(fields, nested, prefix+subPrefix, field.Index, visited)
+				continue
+			}
+			if field.Anonymous && name == "" {
+				fields = appendExcelFields(fields, nested, prefix, field.Index, visited)
+				continue
+			}
+		}
+
+		if name == "" {
+			if hasTag(excelTags[1:], "extra") {
+				fields = append(fields, excelField{field: field, extra: true})
+			}
+			continue
+		}
+		aliases := strings.Split(name, "|")
+		for j, alias := range aliases {
+			aliases[j] = prefix + strings.TrimSpace(alias)
+		}
+		fields = append(fields, excelField{
+			field:   field,
+			name:    aliases[0],
+			aliases: aliases,
+			tags:    excelTags[1:],
+		})
+	}
+	return fields
+}
+
+// nestedStruct returns the struct type of a struct or struct pointer field.
+func nestedStruct(fieldType reflect.Type) reflect.Type {
+	if fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+	if fieldType.Kind() != reflect.Struct {
+		return nil
+	}
+	return fieldType
+}
+
+// tagOption looks up a key=value option of a tag.
+func tagOption(tags []string, key string) (string, bool) {
+	for _, tag := range tags {
+		if k, v, ok := strings.Cut(tag, "="); ok && strings.TrimSpace(k) == key {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// hasTag reports whether the tag options contain option.
+func hasTag(tags []string, option string) bool {
+	for _, tag := range tags {
+		if strings.TrimSpace(tag) == option {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldByIndex returns the nested field of v, allocating the nil struct pointers on the way.
+func fieldByIndex(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
+
+// setField assigns a parsed value to field. Pointer fields get a pointer to the
+// value, and values of a different named type of the same kind are converted.
+// Numbers of another kind are converted if they fit the field, otherwise the
+// returned error wraps errNumberRange.
+func setField(field reflect.Value, value interface{}) error {
+	rv := reflect.ValueOf(value)
+	if !rv.IsValid() {
+		return nil
+	}
+	if field.Kind() == reflect.Ptr && !rv.Type().AssignableTo(field.Type()) {
+		ptr := reflect.New(field.Type().Elem())
+		if err := setField(ptr.Elem(), value); err != nil {
+			return err
+		}
+		field.Set(ptr)
+		return nil
+	}
+	switch {
+	case rv.Type().AssignableTo(field.Type()):
+		field.Set(rv)
+	case rv.Kind() == field.Kind() && rv.Type().ConvertibleTo(field.Type()):
+		field.Set(rv.Convert(field.Type()))
+	case isNumberKind(rv.Kind()) && isNumberKind(field.Kind()):
+		number, err := convertNumber(rv, field.Type())
+		if err != nil {
+			return err
+		}
+		field.Set(number)
+	default:
+		return fmt.Errorf("cannot assign %s to %s", rv.Type(), field.Type())
+	}
+	return nil
+}
+
+// errNumberRange is wrapped by the errors of numbers that do not fit their field.
+var errNumberRange = errors.New("number out of range")
+
+// convertNumber converts the number rv to typ. It fails if the number overflows typ,
+// or if typ is an integer type and the number has a fraction.
+func convertNumber(rv reflect.Value, typ reflect.Type) (reflect.Value, error) {
+	out := reflect.New(typ).Elem()
+	switch {
+	case out.CanInt():
+		var i int64
+		switch {
+		case rv.CanInt():
+			i = rv.Int()
+		case rv.CanUint():
+			if rv.Uint() > math.MaxInt64 {
+				return out, fmt.Errorf("%w: %v overflows %s", errNumberRange, rv, typ)
+			}
+			i = int64(rv.Uint())
+		default:
+			f := rv.Float()
+			if f != math.Trunc(f) {
+				return out, fmt.Errorf("%w: %v has a fraction for %s", errNumberRange, rv, typ)
+			}
+			if f < math.MinInt64 || f >= math.MaxInt64 {
+				return out, fmt.Errorf("%w: %v overflows %s", errNumberRange, rv, typ)
+			}
+			i = int64(f)
+		}
+		if out.OverflowInt(i) {
+			return out, fmt.Errorf("%w: %v overflows %s", errNumberRange, rv, typ)
+		}
+		out.SetInt(i)
+	case out.CanUint():
+		var u uint64
+		switch {
+		case rv.CanInt():
+			if rv.Int() < 0 {
+				return out, fmt.Errorf("%w: %v overflows %s", errNumberRange, rv, typ)
+			}
+			u = uint64(rv.Int())
+		case rv.CanUint():
+			u = rv.Uint()
+		default:
+			f := rv.Float()
+			if f != math.Trunc(f) {
+				return out, fmt.Errorf("%w: %v has a fraction for %s", errNumberRange, rv, typ)
+			}
+			if f < 0 || f >= math.MaxUint64 {
+				return out, fmt.Errorf("%w: %v overflows %s", errNumberRange, rv, typ)
+			}
+			u = uint64(f)
+		}
+		if out.OverflowUint(u) {
+			return out, fmt.Errorf("%w: %v overflows %s", errNumberRange, rv, typ)
+		}
+		out.SetUint(u)
+	default:
+		var f float64
+		switch {
+		case rv.CanInt():
+			f = float64(rv.Int())
+		case rv.CanUint():
+			f = float64(rv.Uint())
+		default:
+			f = rv.Float()
+		}
+		if out.OverflowFloat(f) {
+			return out, fmt.Errorf("%w: %v overflows %s", errNumberRange, rv, typ)
+		}
+		out.SetFloat(f)
+	}
+	return out, nil
+}
+
+func isNumberKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
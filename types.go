@@ -1,5 +1,10 @@
 package excel2struct
 
+import (
+	"regexp"
+	"time"
+)
+
 const (
 	ERROR_UNKNOWN       = 1000
 	ERROR_REQUIRED      = 1001
@@ -7,6 +12,11 @@ const (
 	ERROR_NOT_REGISTED  = 1003
 	ERROR_FIELD_MATCH   = 1004
 	ERROR_EINDEX_EXCEED = 1005
+	ERROR_ENUM          = 1006
+	ERROR_AMBIGUOUS     = 1007
+	ERROR_UNKNOWN_TITLE = 1008
+	ERROR_VALIDATE      = 1009
+	ERROR_ROW           = 1010
 )
 
 var ERROR_TYPE = map[int]string{
@@ -16,22 +26,49 @@ var ERROR_TYPE = map[int]string{
 	ERROR_NOT_REGISTED:  "parsing func is not registered: Parser tag [%s]",
 	ERROR_FIELD_MATCH:   "no excel title matching found: Struct Field [%s]",
 	ERROR_EINDEX_EXCEED: "the Excel column index settings exceed the line length, field [%s]",
+	ERROR_ENUM:          "unknown label [%s] of enum [%s]: field [%s]",
+	ERROR_AMBIGUOUS:     "several excel titles match struct field [%s]: [%s] and [%s]",
+	ERROR_UNKNOWN_TITLE: "excel titles match no struct field: %s",
+	ERROR_VALIDATE:      "field [%s] fails validation rule [%s]: value [%s]",
+	ERROR_ROW:           "row rejected by [%s]: Row [%d], Error [%v]",
 }
 
 type ErrorInfo struct {
-	Row       int
-	Column    string
+	Row       int    // excel row number, counting from 1
+	Col       int    // column index, counting from 0, -1 if the error has no column
+	Column    string // excel column name
+	Cell      string // cell reference, e.g. "D17", empty if the error has no column
+	Sheet     string // sheet name, empty for csv files
+	Value     string // raw cell value
+	Field     string // struct field name
 	ErrorCode int
 	ErrorMsg  string
 }
 
 type FieldMetadata struct {
-	FIndex    int    // struct field index
-	FName     string // struct field name
-	Excel     string // excel column name
-	EIndex    int    // excel column index
-	Parser    string // excel column parser
-	Converter string // struct to excel converter
-	Required  bool
-	Default   string
+	FIndex     int      // struct field index
+	Index      []int    // struct field index path, for fields of nested and embedded structs
+	FName      string   // struct field name
+	Excel      string   // excel column name
+	Aliases    []string // excel column names the column may be titled with, the first is Excel
+	EIndex     int      // excel column index
+	Parser     string   // excel column parser
+	Converter  string   // struct to excel converter
+	Required   bool
+	Default    string
+	Precision  int                    // decimal places the parsed floats and decimals are rounded to, -1 for no rounding
+	Layout     string                 // time layouts of the column, separated by "|"
+	TZ         string                 // time zone of the column, e.g. "Asia/Shanghai"
+	Number     string                 // number locale of the column, e.g. "de"
+	Bool       string                 // bool vocabulary of the column, e.g. "启用|停用"
+	Enum       string                 // name of the enum mapping the labels of the column to codes
+	Regex      string                 // regular expression the title of the column matches
+	Extra      bool                   // catch-all field collecting the columns no other field matches
+	Validate   string                 // validation rules of the column, e.g. "min=0,max=150"
+	parse      FieldParser            // resolved parser of the field, nil if not registered
+	location   *time.Location         // loaded TZ, used by the converter
+	boolValues *BoolValues            // bool vocabulary, used by the converter
+	regex      *regexp.Regexp         // compiled Regex
+	validators []validateRule         // compiled Validate rules
+	enum       map[string]interface{} // registered enum mapping, label to code when parsing and code to label when converting
 }
@@ -1,12 +1,16 @@
 package excel2struct
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"math"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/shopspring/decimal"
+	"github.com/xuri/excelize/v2"
 	"github.com/zeebo/assert"
 )
 
@@ -41,3 +45,221 @@ func TestWriter(t *testing.T) {
 
 	assert.Nil(t, err)
 }
+
+func TestConverterNested(t *testing.T) {
+	ctx := context.Background()
+
+	data := []NestedStruct{
+		{AuditFields: AuditFields{CreatedBy: "admin"}, Name: "Lucas", Shipping: Address{City: "Shanghai", Zip: "200000"}},
+		{Name: "John", Billing: &Address{City: "Beijing"}},
+	}
+
+	f := excelize.NewFile()
+	defer f.Close()
+	streamWriter, err := f.NewStreamWriter("Sheet1")
+	assert.Nil(t, err)
+	assert.Nil(t, NewStructConverter("", "", "Sheet1").Converter(ctx, streamWriter, data))
+	assert.Nil(t, streamWriter.Flush())
+
+	headers, err := f.GetRows("Sheet1")
+	assert.Nil(t, err)
+	assert.DeepEqual(t, headers[0], []string{"createdBy", "name", "Ship City", "Ship Zip", "Bill City", "Bill Zip"})
+
+	buf, err := f.WriteToBuffer()
+	assert.Nil(t, err)
+	excelParser, err := NewExcelParser("xlsx", 0, "Sheet1")
+	assert.Nil(t, err)
+	result, _, err := ReadAs[NestedStruct](ctx, excelParser, bytes.NewReader(buf.Bytes()), true)
+	assert.Nil(t, err)
+	assert.Equal(t, len(result), 2)
+	assert.Equal(t, result[0].Shipping.City, "Shanghai")
+	assert.Equal(t, result[1].Billing.City, "Beijing")
+}
+
+func TestConverterPointer(t *testing.T) {
+	ctx := context.Background()
+
+	name, age := "Lucas", int8(18)
+	data := []PointerStruct{
+		{Name: &name, Age: &age},
+		{},
+	}
+
+	f := excelize.NewFile()
+	defer f.Close()
+	streamWriter, err := f.NewStreamWriter("Sheet1")
+	assert.Nil(t, err)
+	assert.Nil(t, NewStructConverter("", "", "Sheet1").Converter(ctx, streamWriter, data))
+	assert.Nil(t, streamWriter.Flush())
+
+	rows, err := f.GetRows("Sheet1")
+	assert.Nil(t, err)
+	assert.DeepEqual(t, rows[1], []string{"Lucas", "18"})
+	assert.Equal(t, len(rows), 2)
+}
+
+type AmountStruct struct {
+	Price    decimal.Decimal  `excel:"price"`
+	Total    *decimal.Decimal `excel:"total"`
+	Quantity uint16           `excel:"quantity"`
+	Elapsed  time.Duration    `excel:"elapsed"`
+}
+
+func TestConverterDefaults(t *testing.T) {
+	ctx := context.Background()
+
+	total := decimal.RequireFromString("12345678901234567890.12")
+	data := []AmountStruct{
+		{Price: decimal.RequireFromString("0.0035"), Total: &total, Quantity: 65535, Elapsed: 90 * time.Minute},
+	}
+
+	f := excelize.NewFile()
+	defer f.Close()
+	streamWriter, err := f.NewStreamWriter("Sheet1")
+	assert.Nil(t, err)
+	assert.Nil(t, NewStructConverter("", "", "Sheet1").Converter(ctx, streamWriter, data))
+	assert.Nil(t, streamWriter.Flush())
+
+	buf, err := f.WriteToBuffer()
+	assert.Nil(t, err)
+	excelParser, err := NewExcelParser("xlsx", 0, "Sheet1")
+	assert.Nil(t, err)
+	result, rowErrs, err := ReadAs[AmountStruct](ctx, excelParser, bytes.NewReader(buf.Bytes()), true)
+	assert.Nil(t, err)
+	assert.Equal(t, len(rowErrs), 0)
+	assert.That(t, result[0].Price.Equal(data[0].Price))
+	assert.That(t, result[0].Total.Equal(total))
+	assert.Equal(t, result[0].Quantity, uint16(65535))
+	assert.Equal(t, result[0].Elapsed, 90*time.Minute)
+}
+
+// Duration and Decimal share the names of the built-in types but are parsed as TextUnmarshalers.
+type Duration string
+
+func (d *Duration) UnmarshalText(text []byte) error {
+	*d = Duration(strings.ToUpper(string(text)))
+	return nil
+}
+
+type Decimal struct {
+	Text string
+}
+
+func (d Decimal) String() string {
+	return d.Text
+}
+
+func (d *Decimal) UnmarshalText(text []byte) error {
+	d.Text = string(text)
+	return nil
+}
+
+type NamedStruct struct {
+	Elapsed Duration `excel:"elapsed"`
+	Amount  Decimal  `excel:"amount"`
+}
+
+func TestConverterBuiltinNames(t *testing.T) {
+	ctx := context.Background()
+
+	data := []NamedStruct{{Elapsed: "p1d", Amount: Decimal{Text: "abc"}}}
+
+	f := excelize.NewFile()
+	defer f.Close()
+	streamWriter, err := f.NewStreamWriter("Sheet1")
+	assert.Nil(t, err)
+	assert.Nil(t, NewStructConverter("", "", "Sheet1").Converter(ctx, streamWriter, data))
+	assert.Nil(t, streamWriter.Flush())
+
+	buf, err := f.WriteToBuffer()
+	assert.Nil(t, err)
+	excelParser, err := NewExcelParser("xlsx", 0, "Sheet1")
+	assert.Nil(t, err)
+	result, rowErrs, err := ReadAs[NamedStruct](ctx, excelParser, bytes.NewReader(buf.Bytes()), true)
+	assert.Nil(t, err)
+	assert.Equal(t, len(rowErrs), 0)
+	assert.Equal(t, result[0].Elapsed, Duration("P1D"))
+	assert.Equal(t, result[0].Amount, Decimal{Text: "abc"})
+}
+
+func TestConverterLayout(t *testing.T) {
+	ctx := context.Background()
+
+	instant := time.Date(2024, 4, 3, 0, 0, 0, 0, time.UTC)
+	data := []LayoutStruct{
+		{Day: instant, Local: instant},
+	}
+
+	f := excelize.NewFile()
+	defer f.Close()
+	streamWriter, err := f.NewStreamWriter("Sheet1")
+	assert.Nil(t, err)
+	assert.Nil(t, NewStructConverter("", "", "Sheet1").Converter(ctx, streamWriter, data))
+	assert.Nil(t, streamWriter.Flush())
+
+	rows, err := f.GetRows("Sheet1")
+	assert.Nil(t, err)
+	assert.Equal(t, rows[1][0], "03/04/2024")
+
+	buf, err := f.WriteToBuffer()
+	assert.Nil(t, err)
+	excelParser, err := NewExcelParser("xlsx", 0, "Sheet1", WithRawCellValue())
+	assert.Nil(t, err)
+	result, _, err := ReadAs[LayoutStruct](ctx, excelParser, bytes.NewReader(buf.Bytes()), true)
+	assert.Nil(t, err)
+	assert.Equal(t, result[0].Day, instant)
+	assert.That(t, result[0].Local.Equal(instant))
+}
+
+func TestConverterBool(t *testing.T) {
+	ctx := context.Background()
+
+	enabled := true
+	data := []BoolStruct{
+		{IsStaff: true, Enabled: &enabled},
+		{IsStaff: false},
+	}
+
+	f := excelize.NewFile()
+	defer f.Close()
+	streamWriter, err := f.NewStreamWriter("Sheet1")
+	assert.Nil(t, err)
+	converter := NewStructConverter("", "", "Sheet1", WithBoolConverter(BoolValues{True: []string{"是"}, False: []string{"否"}}))
+	assert.Nil(t, converter.Converter(ctx, streamWriter, data))
+	assert.Nil(t, streamWriter.Flush())
+
+	rows, err := f.GetRows("Sheet1")
+	assert.Nil(t, err)
+	assert.DeepEqual(t, rows[1], []string{"是", "启用"})
+	assert.DeepEqual(t, rows[2], []string{"否"})
+
+	result, rowErrs, err := ParseAs[BoolStruct](ctx, mustExcelParser(t), rows, true)
+	assert.Nil(t, err)
+	assert.Equal(t, len(rowErrs), 0)
+	assert.Equal(t, result[0].IsStaff, true)
+	assert.Equal(t, *result[0].Enabled, true)
+	assert.Nil(t, result[1].Enabled)
+}
+
+func TestConverterEnum(t *testing.T) {
+	ctx := context.Background()
+
+	channel := 9
+	data := []EnumStruct{
+		{OrderNo: "A001", Status: 2},
+		{OrderNo: "A002", Status: 0, Channel: &channel},
+	}
+
+	f := excelize.NewFile()
+	defer f.Close()
+	streamWriter, err := f.NewStreamWriter("Sheet1")
+	assert.Nil(t, err)
+	converter := NewStructConverter("", "", "Sheet1", WithEnumConverter("orderStatus", orderStatus))
+	assert.Nil(t, converter.Converter(ctx, streamWriter, data))
+	assert.Nil(t, streamWriter.Flush())
+
+	rows, err := f.GetRows("Sheet1")
+	assert.Nil(t, err)
+	assert.DeepEqual(t, rows[1], []string{"A001", "已发货"})
+	assert.DeepEqual(t, rows[2], []string{"A002", "待付款", "9"})
+}